@@ -2,19 +2,22 @@ package main
 
 import (
 	"log"
-	"os"
 
 	"github.com/tcnksm/go-input"
 )
 
 func main() {
 
-	ui := &input.UI{}
+	ui := &input.UI{
+		// Use the NAME env var as the answer if it's set
+		Resolvers: []input.Resolver{
+			&input.EnvResolver{},
+		},
+	}
 
 	query := "What is your name?"
 	name, err := ui.Ask(query, &input.Options{
-		// Read the default val from env var
-		Default:  os.Getenv("NAME"),
+		Key:      "name",
 		Required: true,
 		Loop:     true,
 	})
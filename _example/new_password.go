@@ -0,0 +1,28 @@
+package main
+
+import (
+	"log"
+
+	"github.com/tcnksm/go-input"
+)
+
+func main() {
+	ui := &input.UI{}
+
+	query := "Choose a new password"
+	password, err := ui.AskNewPassword(query, &input.PasswordPolicy{
+		MinLength:    12,
+		RequireDigit: true,
+		DenyList:     []string{"password123", "qwertyuiop12"},
+		MinEntropy:   50,
+	}, &input.Options{
+		Loop:          true,
+		MaxAttempts:   5,
+		StrengthMeter: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Password has %d characters\n", len(password))
+}
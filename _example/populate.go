@@ -0,0 +1,28 @@
+package main
+
+import (
+	"log"
+
+	"github.com/tcnksm/go-input"
+)
+
+type config struct {
+	Name     string `input:"name,required" prompt:"What is your name?"`
+	Password string `prompt:"What is your password?" mask:"true"`
+	Region   string `choices:"osaka,tokyo,nagoya"`
+	DB       struct {
+		Host string `default:"localhost"`
+		Port int    `default:"5432"`
+	}
+}
+
+func main() {
+	ui := &input.UI{}
+
+	var cfg config
+	if err := ui.Populate(&cfg); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Config is %+v\n", cfg)
+}
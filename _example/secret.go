@@ -0,0 +1,24 @@
+package main
+
+import (
+	"log"
+
+	"github.com/tcnksm/go-input"
+)
+
+func main() {
+	ui := &input.UI{}
+
+	query := "What is your private key passphrase?"
+	secret, err := ui.AskSecret(query, &input.Options{
+		Required: true,
+		Loop:     true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Wipe the secret from the memory after use
+	defer secret.Wipe()
+
+	log.Printf("Passphrase has %d bytes\n", len(secret.Bytes()))
+}
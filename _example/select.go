@@ -17,6 +17,8 @@ func main() {
 	lang, err := ui.Select(query, []string{"go", "Go", "golang"}, &input.Options{
 		Default: "Go",
 		Loop:    true,
+		// Select by arrow keys when running on a terminal
+		Interactive: true,
 	})
 	if err != nil {
 		log.Fatal(err)
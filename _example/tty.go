@@ -0,0 +1,33 @@
+package main
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/tcnksm/go-input"
+)
+
+// Run this with the piped input, e.g., `echo hello | go run tty.go`.
+func main() {
+	data, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Ask the user on the terminal even though stdin is piped
+	ui, err := input.TTYUI()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	query := "Do you apply the input?"
+	ans, err := ui.Confirm(query, &input.ConfirmOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if ans {
+		log.Printf("Applied %d bytes\n", len(data))
+	}
+}
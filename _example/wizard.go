@@ -0,0 +1,45 @@
+package main
+
+import (
+	"log"
+
+	"github.com/tcnksm/go-input"
+)
+
+func main() {
+	w := &input.Wizard{
+		Steps: []input.Step{
+			{
+				Key:     "name",
+				Query:   "What is your name?",
+				Options: input.Options{Required: true, Loop: true},
+			},
+			{
+				Key:     "env",
+				Query:   "Which environment?",
+				Choices: []string{"dev", "prod"},
+				Options: input.Options{Loop: true},
+			},
+			{
+				// Asked only for prod
+				Key:   "region",
+				Query: "Which region?",
+				When: func(answers input.Answers) bool {
+					return answers["env"] == "prod"
+				},
+				Options: input.Options{Default: "us-east-1"},
+			},
+		},
+
+		// Confirm the answers before returning them
+		Review: true,
+	}
+
+	// Enter "<" to go back to the previous question
+	answers, err := w.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Answers are %v\n", answers)
+}
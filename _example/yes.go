@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"log"
 	"os"
 
@@ -14,21 +13,14 @@ func main() {
 		Reader: os.Stdin,
 	}
 
-	query := "Do you love golang [Y/n]"
-	name, err := ui.Ask(query, &input.Options{
-		Required: true,
-		// Validate input
-		ValidateFunc: func(s string) error {
-			if s != "Y" && s != "n" {
-				return fmt.Errorf("input must be Y or n")
-			}
-
-			return nil
-		},
+	query := "Do you love golang?"
+	ans, err := ui.Confirm(query, &input.ConfirmOptions{
+		Default: true,
+		Loop:    true,
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	log.Printf("Answer is %s\n", name)
+	log.Printf("Answer is %v\n", ans)
 }
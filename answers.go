@@ -0,0 +1,458 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Formats of the answers file.
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// AnswersResolver resolves the answers of the answers file by the keys
+// of the prompts. The values are string, or []string for MultiSelect.
+// The lists are resolved as they are by ResolveList, so the labels
+// which contain comma and the empty selection are replayed.
+type AnswersResolver map[string]interface{}
+
+// Resolve returns the value of the key. The list is joined by comma.
+func (a AnswersResolver) Resolve(key string) (string, bool) {
+	switch v := a[key].(type) {
+	case string:
+		return v, true
+	case []string:
+		return strings.Join(v, ","), true
+	}
+
+	return "", false
+}
+
+// ResolveList returns the list of the key. The string value is split
+// by comma.
+func (a AnswersResolver) ResolveList(key string) ([]string, bool) {
+	switch v := a[key].(type) {
+	case string:
+		if v == "" {
+			return nil, false
+		}
+		return splitList(v), true
+	case []string:
+		return v, true
+	}
+
+	return nil, false
+}
+
+// LoadAnswers reads the answers file and returns Resolver which
+// resolves the answers by the keys of the prompts. The format is
+// decided by the extension of the file (.yaml or .yml for YAML and
+// JSON for the others). See ParseAnswers.
+func LoadAnswers(path string) (AnswersResolver, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := ParseAnswers(data, formatOf(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+
+	return answers, nil
+}
+
+// ParseAnswers parses the answers in the given format (FormatJSON or
+// FormatYAML). The answers are the flat mapping from the keys to the
+// values. The values are strings, numbers, booleans (for Confirm) or
+// lists of strings (for MultiSelect). Select is answered by the label
+// of the item, not by the number. Only the subset of YAML which is
+// written by Recorder (and the flow lists, quotes and comments) is
+// supported.
+func ParseAnswers(data []byte, format string) (AnswersResolver, error) {
+	switch format {
+	case FormatJSON:
+		return parseJSONAnswers(data)
+	case FormatYAML:
+		return parseYAMLAnswers(data)
+	}
+
+	return nil, fmt.Errorf("unknown format of answers: %s", format)
+}
+
+// formatOf returns the format of the answers file by its extension.
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	}
+
+	return FormatJSON
+}
+
+// parseJSONAnswers parses the answers in JSON.
+func parseJSONAnswers(data []byte) (AnswersResolver, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var raw map[string]interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	answers := AnswersResolver{}
+	for key, v := range raw {
+		if v == nil {
+			continue
+		}
+
+		if list, ok := v.([]interface{}); ok {
+			items := make([]string, len(list))
+			for i, item := range list {
+				s, err := jsonScalar(item)
+				if err != nil {
+					return nil, fmt.Errorf("%q: %s", key, err)
+				}
+				items[i] = s
+			}
+
+			answers[key] = items
+			continue
+		}
+
+		s, err := jsonScalar(v)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %s", key, err)
+		}
+		answers[key] = s
+	}
+
+	return answers, nil
+}
+
+// jsonScalar converts the scalar value of JSON to string.
+func jsonScalar(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+
+	return "", fmt.Errorf("unsupported value: %v", v)
+}
+
+// parseYAMLAnswers parses the answers in YAML. It supports the flat
+// mapping whose values are the scalars or the lists.
+func parseYAMLAnswers(data []byte) (AnswersResolver, error) {
+	answers := AnswersResolver{}
+
+	// listKey is the key of the block list which is being read
+	var listKey string
+	var list []string
+	flush := func() {
+		// The key without the items is null
+		if listKey != "" && list == nil {
+			answers[listKey] = ""
+		} else if listKey != "" {
+			answers[listKey] = list
+		}
+		listKey, list = "", nil
+	}
+
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// The item of the block list
+		if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+			if listKey == "" {
+				return nil, fmt.Errorf("line %d: unexpected list item", n+1)
+			}
+
+			v, err := yamlScalar(strings.TrimSpace(trimmed[1:]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", n+1, err)
+			}
+
+			list = append(list, v)
+			continue
+		}
+		flush()
+
+		if trimmed != line {
+			return nil, fmt.Errorf("line %d: nested mapping is not supported", n+1)
+		}
+
+		key, value, err := yamlKeyValue(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n+1, err)
+		}
+
+		// The value is the block list which follows
+		if value == "" {
+			listKey = key
+			continue
+		}
+
+		// The flow list, e.g., [a, b]
+		if strings.HasPrefix(value, "[") {
+			value = stripYAMLComment(value)
+			if !strings.HasSuffix(value, "]") {
+				return nil, fmt.Errorf("line %d: unterminated list", n+1)
+			}
+
+			items := []string{}
+			if strings.TrimSpace(value[1:len(value)-1]) == "" {
+				answers[key] = items
+				continue
+			}
+
+			for _, item := range strings.Split(value[1:len(value)-1], ",") {
+				v, err := yamlScalar(strings.TrimSpace(item))
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s", n+1, err)
+				}
+				items = append(items, v)
+			}
+
+			answers[key] = items
+			continue
+		}
+
+		v, err := yamlScalar(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n+1, err)
+		}
+		answers[key] = v
+	}
+	flush()
+
+	return answers, nil
+}
+
+// yamlKeyValue splits the line of the mapping into the key and the
+// value.
+func yamlKeyValue(line string) (string, string, error) {
+	var key, rest string
+	if strings.HasPrefix(line, `"`) || strings.HasPrefix(line, "'") {
+		end := quotedEnd(line)
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated quoted key")
+		}
+
+		k, err := yamlScalar(line[:end])
+		if err != nil {
+			return "", "", err
+		}
+
+		key, rest = k, strings.TrimLeft(line[end:], " \t")
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", fmt.Errorf("missing ':' after key")
+		}
+		rest = rest[1:]
+	} else {
+		i := strings.Index(line+" ", ": ")
+		if i < 0 {
+			return "", "", fmt.Errorf("missing ':' after key")
+		}
+		key, rest = strings.TrimSpace(line[:i]), line[i+1:]
+	}
+
+	return key, strings.TrimSpace(rest), nil
+}
+
+// yamlScalar parses the scalar value of YAML. The comment after the
+// value is removed.
+func yamlScalar(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		end := quotedEnd(s)
+		if end < 0 || stripYAMLComment(s[end:]) != "" {
+			return "", fmt.Errorf("invalid quoted value: %s", s)
+		}
+		return strconv.Unquote(s[:end])
+	case strings.HasPrefix(s, "'"):
+		end := quotedEnd(s)
+		if end < 0 || stripYAMLComment(s[end:]) != "" {
+			return "", fmt.Errorf("invalid quoted value: %s", s)
+		}
+		return strings.Replace(s[1:end-1], "''", "'", -1), nil
+	}
+
+	s = stripYAMLComment(s)
+	if s == "~" || s == "null" {
+		return "", nil
+	}
+
+	return s, nil
+}
+
+// quotedEnd returns the index after the closing quote of the quoted
+// string which s starts with. It returns -1 if it's not closed.
+func quotedEnd(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i + 1
+		}
+	}
+
+	return -1
+}
+
+// stripYAMLComment removes the comment from the unquoted value.
+func stripYAMLComment(s string) string {
+	if strings.HasPrefix(s, "#") {
+		return ""
+	}
+
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = s[:i]
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// Recorder records the answers of the prompts which have keys (see
+// Options.Key) so that they can be written to the answers file and
+// replayed by LoadAnswers, e.g., record once on the terminal and replay
+// in CI. The masked or hidden answers are not recorded. Set it to
+// UI.Recorder. The zero value is ready to use.
+type Recorder struct {
+	mu      sync.Mutex
+	answers map[string]interface{}
+}
+
+// record records the answer of the key. The value is string, []string
+// or bool.
+func (r *Recorder) record(key string, v interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.answers == nil {
+		r.answers = make(map[string]interface{})
+	}
+
+	// The empty selection is recorded as the empty list, not null
+	if list, ok := v.([]string); ok && list == nil {
+		v = []string{}
+	}
+	r.answers[key] = v
+}
+
+// WriteFile writes the recorded answers to the file. The format is
+// decided by the extension of the file same as LoadAnswers.
+func (r *Recorder) WriteFile(path string) error {
+	var buf bytes.Buffer
+	if err := r.Encode(&buf, formatOf(path)); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, buf.Bytes(), 0600)
+}
+
+// Encode writes the recorded answers to w in the given format
+// (FormatJSON or FormatYAML).
+func (r *Recorder) Encode(w io.Writer, format string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	answers := r.answers
+	if answers == nil {
+		answers = map[string]interface{}{}
+	}
+
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(answers, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	case FormatYAML:
+		_, err := io.WriteString(w, encodeYAML(answers))
+		return err
+	}
+
+	return fmt.Errorf("unknown format of answers: %s", format)
+}
+
+// encodeYAML encodes the answers in YAML. The keys are sorted.
+func encodeYAML(answers map[string]interface{}) string {
+	keys := make([]string, 0, len(answers))
+	for key := range answers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		switch v := answers[key].(type) {
+		case []string:
+			if len(v) == 0 {
+				buf.WriteString(fmt.Sprintf("%s: []\n", yamlQuote(key)))
+				continue
+			}
+
+			buf.WriteString(fmt.Sprintf("%s:\n", yamlQuote(key)))
+			for _, item := range v {
+				buf.WriteString(fmt.Sprintf("  - %s\n", yamlQuote(item)))
+			}
+		case bool:
+			buf.WriteString(fmt.Sprintf("%s: %t\n", yamlQuote(key), v))
+		default:
+			buf.WriteString(fmt.Sprintf("%s: %s\n", yamlQuote(key), yamlQuote(fmt.Sprint(v))))
+		}
+	}
+
+	return buf.String()
+}
+
+// yamlQuote quotes s if it can't be written as the plain scalar.
+func yamlQuote(s string) string {
+	if s == "" || s == "~" || s == "null" || strings.TrimSpace(s) != s ||
+		strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") ||
+		strings.Contains(s, ": ") || strings.Contains(s, " #") ||
+		strings.HasSuffix(s, ":") || strings.IndexFunc(s, isControl) >= 0 {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+// isControl reports whether r is a control character.
+func isControl(r rune) bool {
+	return r < ' ' || r == 0x7f
+}
+
+// recordAnswer records the answer to UI.Recorder if the prompt has
+// the key and the answer is not masked.
+func (i *UI) recordAnswer(opts *Options, v interface{}) {
+	if i.Recorder == nil || opts.Key == "" || opts.Mask || opts.Hide {
+		return
+	}
+
+	i.Recorder.record(opts.Key, v)
+}
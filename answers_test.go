@@ -0,0 +1,269 @@
+package input
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseAnswers(t *testing.T) {
+	expect := AnswersResolver{
+		"name":    "tcnksm",
+		"age":     "30",
+		"apply":   "true",
+		"region":  "tokyo",
+		"regions": []string{"osaka", "tokyo"},
+		"none":    []string{},
+		"message": "hello: world # not comment",
+	}
+
+	cases := []struct {
+		format string
+		data   string
+	}{
+		{
+			format: FormatJSON,
+			data: `{
+  "name": "tcnksm",
+  "age": 30,
+  "apply": true,
+  "region": "tokyo",
+  "regions": ["osaka", "tokyo"],
+  "none": [],
+  "message": "hello: world # not comment",
+  "nothing": null
+}`,
+		},
+
+		{
+			format: FormatYAML,
+			data: `---
+# Answers
+name: tcnksm
+age: 30 # comment
+apply: true
+"region": 'tokyo'
+regions:
+  - osaka
+  - "tokyo"
+none: [] # empty
+message: "hello: world # not comment"
+nothing: ~
+`,
+		},
+
+		{
+			format: FormatYAML,
+			data: `name: tcnksm
+age: 30
+apply: true
+region: tokyo
+regions: [osaka, tokyo]
+none: []
+message: 'hello: world # not comment'
+`,
+		},
+	}
+
+	for i, c := range cases {
+		answers, err := ParseAnswers([]byte(c.data), c.format)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		delete(answers, "nothing")
+		if !reflect.DeepEqual(answers, expect) {
+			t.Fatalf("#%d expect %v to be eq %v", i, answers, expect)
+		}
+	}
+}
+
+func TestParseAnswers_invalid(t *testing.T) {
+	cases := []struct {
+		format string
+		data   string
+	}{
+		{format: FormatJSON, data: `{"db": {"host": "localhost"}}`},
+		{format: FormatYAML, data: "db:\n  host: localhost\n"},
+		{format: FormatYAML, data: "- osaka\n"},
+		{format: FormatYAML, data: "name\n"},
+		{format: FormatYAML, data: "name: \"tcnksm\n"},
+		{format: "toml", data: "name = \"tcnksm\"\n"},
+	}
+
+	for i, c := range cases {
+		if _, err := ParseAnswers([]byte(c.data), c.format); err == nil {
+			t.Fatalf("#%d expect error to occurr", i)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	session := func(ui *UI) []interface{} {
+		name, err := ui.Ask("What is your name?", &Options{Key: "name", Default: "taichi"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		password, err := ui.Ask("Password?", &Options{Key: "password", Mask: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		apply, err := ui.Confirm("Apply?", &ConfirmOptions{Key: "apply"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		region, err := ui.Select("Which region?", []string{"osaka", "tokyo: east"}, &Options{Key: "region"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		regions, err := ui.MultiSelect("Which regions?", []string{"osaka", "tokyo", "nagoya"}, &MultiSelectOptions{
+			Options: Options{Key: "regions"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return []interface{}{name, password, apply, region, regions}
+	}
+
+	for _, file := range []string{"answers.json", "answers.yaml"} {
+		path := filepath.Join(dir, file)
+
+		// Record the answers of the interactive session
+		recorder := &Recorder{}
+		ui := &UI{
+			Writer:   ioutil.Discard,
+			Reader:   bytes.NewBufferString("\npassw0rd\ny\n2\n3,1\n"),
+			Recorder: recorder,
+		}
+
+		recorded := session(ui)
+		if err := recorder.WriteFile(path); err != nil {
+			t.Fatalf("%s: expect not to occurr error: %s", file, err)
+		}
+
+		answers, err := LoadAnswers(path)
+		if err != nil {
+			t.Fatalf("%s: expect not to occurr error: %s", file, err)
+		}
+
+		// The masked answer is not recorded
+		if _, ok := answers["password"]; ok {
+			t.Fatalf("%s: expect the masked answer not to be recorded", file)
+		}
+
+		// Replay the session without the input
+		ui = &UI{
+			Writer:         ioutil.Discard,
+			Reader:         bytes.NewBufferString(""),
+			NonInteractive: true,
+			Resolvers: []Resolver{
+				answers,
+				MapResolver{"password": "passw0rd"},
+			},
+		}
+
+		replayed := session(ui)
+		if !reflect.DeepEqual(replayed, recorded) {
+			t.Fatalf("%s: expect %v to be eq %v", file, replayed, recorded)
+		}
+	}
+}
+
+func TestRecorder_list(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	list := []string{"a, b", "c"}
+	session := func(ui *UI) [][]string {
+		var selected [][]string
+		for _, key := range []string{"comma", "empty"} {
+			items, err := ui.MultiSelect("Which items?", list, &MultiSelectOptions{
+				Options: Options{Key: key},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			selected = append(selected, items)
+		}
+
+		return selected
+	}
+
+	for _, file := range []string{"answers.json", "answers.yaml"} {
+		path := filepath.Join(dir, file)
+
+		// The label which contains comma and the empty selection
+		recorder := &Recorder{}
+		ui := &UI{
+			Writer:   ioutil.Discard,
+			Reader:   bytes.NewBufferString("1\n\n"),
+			Recorder: recorder,
+		}
+
+		recorded := session(ui)
+		if err := recorder.WriteFile(path); err != nil {
+			t.Fatalf("%s: expect not to occurr error: %s", file, err)
+		}
+
+		answers, err := LoadAnswers(path)
+		if err != nil {
+			t.Fatalf("%s: expect not to occurr error: %s", file, err)
+		}
+
+		ui = &UI{
+			Writer:         ioutil.Discard,
+			Reader:         bytes.NewBufferString(""),
+			NonInteractive: true,
+			Resolvers:      []Resolver{answers},
+		}
+
+		replayed := session(ui)
+		expect := [][]string{{"a, b"}, {}}
+		if !reflect.DeepEqual(recorded, expect) || !reflect.DeepEqual(replayed, expect) {
+			t.Fatalf("%s: expect %q and %q to be eq %q", file, recorded, replayed, expect)
+		}
+	}
+}
+
+func TestRecorder_Encode(t *testing.T) {
+	recorder := &Recorder{}
+	recorder.record("name", "tcnksm")
+	recorder.record("empty", "")
+	recorder.record("apply", false)
+	recorder.record("regions", []string{"osaka", "- tokyo"})
+	recorder.record("none", []string(nil))
+
+	var buf bytes.Buffer
+	if err := recorder.Encode(&buf, FormatYAML); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	expect := `apply: false
+empty: ""
+name: tcnksm
+none: []
+regions:
+  - osaka
+  - "- tokyo"
+`
+	if buf.String() != expect {
+		t.Fatalf("expect %q to be eq %q", buf.String(), expect)
+	}
+}
@@ -2,17 +2,65 @@ package input
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 )
 
 // Ask asks the user for input using the given query. The response is
 // returned as string. Error is returned based on the given option.
-// If Loop is true, it continue to ask until it receives valid input.
+// If Loop is true, it continue to ask until it receives valid input
+// or the input is rejected MaxAttempts times.
+//
+// If the input ends (EOF) before anything is input, it returns ErrEOF.
 //
 // If the user sends SIGINT (Ctrl+C) while reading input, it catches
 // it and return it as a error.
 func (i *UI) Ask(query string, opts *Options) (string, error) {
-	i.once.Do(i.setDefault)
+	return i.ask(context.Background(), query, "", opts, opts.validate)
+}
+
+// AskContext asks the user for input same as Ask, but returns ctx.Err()
+// when the context is done before the user answers. If the deadline of
+// the context is exceeded and DefaultOnTimeout is true, it returns
+// Default instead.
+func (i *UI) AskContext(ctx context.Context, query string, opts *Options) (string, error) {
+	return i.ask(ctx, query, "", opts, opts.validate)
+}
+
+// ask is the implementation of Ask. hint is added to the instruction
+// line (e.g., the allowed range of the value) and validate is used
+// to validate the input instead of opts.ValidateFunc.
+func (i *UI) ask(ctx context.Context, query, hint string, opts *Options, validate ValidateAttemptFunc) (answer string, err error) {
+	if err := i.setup(); err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err == nil {
+			i.recordAnswer(opts, answer)
+		}
+	}()
+
+	// Use the resolved answer without asking if it's valid
+	if resolved, ok := i.resolve(opts.Key); ok {
+		err := validate(resolved, 1)
+		if err == nil {
+			return resolved, nil
+		}
+
+		if err := i.rejectResolved(opts, err); err != nil {
+			return "", err
+		}
+	}
+
+	// Use the default value without asking in non-interactive mode
+	if i.nonInteractive() {
+		if opts.Default == "" {
+			return "", &NonInteractiveError{Query: query}
+		}
+
+		return opts.Default, nil
+	}
 
 	// Display the query to the user.
 	fmt.Fprintf(i.Writer, "%s", query)
@@ -21,6 +69,8 @@ func (i *UI) Ask(query string, opts *Options) (string, error) {
 	var resultStr string
 	var resultErr error
 
+	// attempt is incremented when the input is rejected
+	attempt := 1
 	loopCount := 0
 	for {
 		loopCount++
@@ -29,6 +79,7 @@ func (i *UI) Ask(query string, opts *Options) (string, error) {
 		var buf bytes.Buffer
 		if !opts.HideOrder || loopCount > 1 {
 			buf.WriteString("\nEnter a value")
+			buf.WriteString(hint)
 		}
 
 		if opts.Default != "" && !opts.HideDefault {
@@ -41,15 +92,26 @@ func (i *UI) Ask(query string, opts *Options) (string, error) {
 
 		// Display the instruction to user and ask to input.
 		buf.WriteString(": ")
-		fmt.Fprintf(i.Writer, buf.String())
+		fmt.Fprint(i.Writer, buf.String())
 
 		// Read user input from UI.Reader.
-		line, err := i.read(opts.readOpts())
+		line, err := i.readContext(ctx, opts.readOpts())
+		if err == context.DeadlineExceeded && opts.DefaultOnTimeout && opts.Default != "" {
+			resultStr = opts.Default
+			break
+		}
+
 		if err != nil {
 			resultErr = err
 			break
 		}
 
+		// Go back to the previous question
+		if opts.BackCommand != "" && line == opts.BackCommand {
+			resultErr = ErrBack
+			break
+		}
+
 		// line is empty but default is provided returns it
 		if line == "" && opts.Default != "" {
 			resultStr = opts.Default
@@ -57,23 +119,24 @@ func (i *UI) Ask(query string, opts *Options) (string, error) {
 		}
 
 		if line == "" && opts.Required {
-			if !opts.Loop {
-				resultErr = ErrEmpty
+			if err := opts.giveUp(attempt, ErrEmpty); err != nil {
+				resultErr = err
 				break
 			}
 
+			attempt++
 			fmt.Fprintf(i.Writer, "Input must not be empty.\n\n")
 			continue
 		}
 
 		// validate input by custom fuction
-		validate := opts.validateFunc()
-		if err := validate(line); err != nil {
-			if !opts.Loop {
+		if err := validate(line, attempt); err != nil {
+			if err := opts.giveUp(attempt, err); err != nil {
 				resultErr = err
 				break
 			}
 
+			attempt++
 			fmt.Fprintf(i.Writer, "Failed to validate input string: %s\n\n", err)
 			continue
 		}
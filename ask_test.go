@@ -2,10 +2,12 @@ package input
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"testing"
+	"time"
 )
 
 func TestAsk(t *testing.T) {
@@ -56,6 +58,70 @@ func TestAsk(t *testing.T) {
 	}
 }
 
+func TestAskContext(t *testing.T) {
+	// The reader which never returns the input
+	r, w := io.Pipe()
+	defer w.Close()
+
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: r,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ui.AskContext(ctx, "", &Options{})
+	if err != context.Canceled {
+		t.Fatalf("expect %q to be eq %q", err, context.Canceled)
+	}
+}
+
+func TestAskContext_timeout(t *testing.T) {
+	cases := []struct {
+		opts      *Options
+		expect    string
+		expectErr error
+	}{
+		{
+			opts: &Options{
+				Default: "tcnksm",
+			},
+			expectErr: context.DeadlineExceeded,
+		},
+
+		{
+			opts: &Options{
+				Default:          "tcnksm",
+				DefaultOnTimeout: true,
+			},
+			expect: "tcnksm",
+		},
+	}
+
+	for i, c := range cases {
+		r, w := io.Pipe()
+		defer w.Close()
+
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: r,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		ans, err := ui.AskContext(ctx, "", c.opts)
+		if err != c.expectErr {
+			t.Fatalf("#%d expect %v to be eq %v", i, err, c.expectErr)
+		}
+
+		if ans != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, ans, c.expect)
+		}
+	}
+}
+
 func ExampleUI_Ask() {
 	ui := &UI{
 		// In real world, Reader is os.Stdin and input comes
@@ -70,3 +136,36 @@ func ExampleUI_Ask() {
 	fmt.Println(name)
 	// Output: tcnksm
 }
+
+func TestAsk_EOF(t *testing.T) {
+	cases := []struct {
+		opts      *Options
+		userInput string
+	}{
+		{
+			opts:      &Options{},
+			userInput: "",
+		},
+
+		// It must not loop forever
+		{
+			opts: &Options{
+				Required: true,
+				Loop:     true,
+			},
+			userInput: "\n",
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: bytes.NewBufferString(c.userInput),
+		}
+
+		_, err := ui.Ask("", c.opts)
+		if err != ErrEOF {
+			t.Fatalf("#%d expect %v to be eq %v", i, err, ErrEOF)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package input
+
+import "fmt"
+
+// TooManyAttemptsError is returned when the input is rejected
+// Options.MaxAttempts times. It matches ErrTooManyAttempts with
+// errors.Is and unwraps to the error of the last attempt.
+type TooManyAttemptsError struct {
+	// Attempts is the number of the attempts.
+	Attempts int
+
+	// Err is the error of the last attempt.
+	Err error
+}
+
+// Error implements error.
+func (e *TooManyAttemptsError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrTooManyAttempts, e.Err)
+}
+
+// Is reports whether target is ErrTooManyAttempts.
+func (e *TooManyAttemptsError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *TooManyAttemptsError) Unwrap() error {
+	return e.Err
+}
+
+// giveUp returns the error to stop asking when the given attempt is
+// rejected by err. It returns nil if it can ask again, i.e., Loop is
+// true and the attempts don't reach MaxAttempts.
+func (o *Options) giveUp(attempt int, err error) error {
+	if !o.Loop {
+		return err
+	}
+
+	if o.MaxAttempts > 0 && attempt >= o.MaxAttempts {
+		return &TooManyAttemptsError{Attempts: attempt, Err: err}
+	}
+
+	return nil
+}
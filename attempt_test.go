@@ -0,0 +1,112 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+)
+
+func TestMaxAttempts(t *testing.T) {
+	cases := []struct {
+		f         func(ui *UI) error
+		userInput string
+		expectErr error
+	}{
+		{
+			f: func(ui *UI) error {
+				_, err := ui.Ask("", &Options{Required: true, Loop: true, MaxAttempts: 2})
+				return err
+			},
+			userInput: "\n\ntcnksm\n",
+			expectErr: ErrEmpty,
+		},
+
+		{
+			f: func(ui *UI) error {
+				_, err := ui.AskInt("", &IntRange{Min: intp(1), Max: intp(10)}, &Options{Loop: true, MaxAttempts: 3})
+				return err
+			},
+			userInput: "a\n0\n11\n5\n",
+			expectErr: ErrOutOfRange,
+		},
+
+		{
+			f: func(ui *UI) error {
+				_, err := ui.Select("", []string{"A", "B"}, &Options{Loop: true, MaxAttempts: 2})
+				return err
+			},
+			userInput: "3\n0\n1\n",
+			expectErr: ErrOutOfRange,
+		},
+
+		{
+			f: func(ui *UI) error {
+				_, err := ui.MultiSelect("", []string{"A", "B"}, &MultiSelectOptions{
+					Options: Options{Loop: true, MaxAttempts: 2},
+					Max:     1,
+				})
+				return err
+			},
+			userInput: "1,2\nall\n1\n",
+			expectErr: ErrSelectionCount,
+		},
+
+		{
+			f: func(ui *UI) error {
+				_, err := ui.Confirm("", &ConfirmOptions{Loop: true, MaxAttempts: 1})
+				return err
+			},
+			userInput: "maybe\ny\n",
+			expectErr: ErrNotYesNo,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: bytes.NewBufferString(c.userInput),
+		}
+
+		err := c.f(ui)
+		if !errors.Is(err, ErrTooManyAttempts) {
+			t.Fatalf("#%d expect %v to be ErrTooManyAttempts", i, err)
+		}
+
+		// The error wraps the error of the last attempt
+		if !errors.Is(err, c.expectErr) {
+			t.Fatalf("#%d expect %v to wrap %v", i, err, c.expectErr)
+		}
+	}
+}
+
+func TestValidateAttemptFunc(t *testing.T) {
+	var attempts []int
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("a\nb\nc\n"),
+	}
+
+	ans, err := ui.Ask("", &Options{
+		Loop: true,
+		ValidateAttemptFunc: func(s string, attempt int) error {
+			attempts = append(attempts, attempt)
+			if s != "c" {
+				return fmt.Errorf("input must be c")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if ans != "c" {
+		t.Fatalf("expect %q to be eq %q", ans, "c")
+	}
+
+	if fmt.Sprint(attempts) != "[1 2 3]" {
+		t.Fatalf("expect %v to be eq %v", attempts, "[1 2 3]")
+	}
+}
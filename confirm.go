@@ -0,0 +1,91 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConfirmOptions is structure contains option for Confirm.
+type ConfirmOptions struct {
+	// Key is the stable key of the question which is used to
+	// resolve the answer. See Options.Key.
+	Key string
+
+	// Default is the answer which is used when nothing is input.
+	// It also decides the hint shown after the query, [Y/n] when
+	// it's true and [y/N] when it's false.
+	Default bool
+
+	// Loop loops asking user to input until getting valid input.
+	Loop bool
+
+	// MaxAttempts is the maximum number of the attempts in Loop.
+	// See Options.MaxAttempts.
+	MaxAttempts int
+
+	// HideOrder hides order comment ('Enter a value')
+	HideOrder bool
+}
+
+// Confirm asks the user a yes or no question using the given query.
+// It accepts y, yes, n and no (and true and false, which are used in
+// the answers file) in any case and returns the answer as bool. If
+// nothing is input, it returns opts.Default. If Loop is true, it
+// continue to ask until it receives valid input. In non-interactive
+// mode, it returns opts.Default without asking.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func (i *UI) Confirm(query string, opts *ConfirmOptions) (yes bool, err error) {
+	if err := i.setup(); err != nil {
+		return false, err
+	}
+
+	// The answer is recorded as bool
+	defer func() {
+		if err == nil {
+			i.recordAnswer(&Options{Key: opts.Key}, yes)
+		}
+	}()
+
+	// The default answer is always provided
+	if _, ok := i.resolve(opts.Key); !ok && i.nonInteractive() {
+		return opts.Default, nil
+	}
+
+	hint := "[y/N]"
+	if opts.Default {
+		hint = "[Y/n]"
+	}
+
+	ans, err := i.Ask(fmt.Sprintf("%s %s", query, hint), &Options{
+		Key:         opts.Key,
+		Loop:        opts.Loop,
+		MaxAttempts: opts.MaxAttempts,
+		HideOrder:   opts.HideOrder,
+		ValidateFunc: func(s string) error {
+			_, err := parseYesNo(s, opts.Default)
+			return err
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return parseYesNo(ans, opts.Default)
+}
+
+// parseYesNo converts the answer of yes or no question to bool.
+// Empty answer is converted to def.
+func parseYesNo(s string, def bool) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		return def, nil
+	case "y", "yes", "true":
+		return true, nil
+	case "n", "no", "false":
+		return false, nil
+	}
+
+	return false, ErrNotYesNo
+}
@@ -0,0 +1,139 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	cases := []struct {
+		opts      *ConfirmOptions
+		userInput io.Reader
+		expect    bool
+	}{
+		{
+			opts:      &ConfirmOptions{},
+			userInput: bytes.NewBufferString("y\n"),
+			expect:    true,
+		},
+
+		{
+			opts:      &ConfirmOptions{},
+			userInput: bytes.NewBufferString("YES\n"),
+			expect:    true,
+		},
+
+		{
+			opts: &ConfirmOptions{
+				Default: true,
+			},
+			userInput: bytes.NewBufferString("No\n"),
+			expect:    false,
+		},
+
+		{
+			opts:      &ConfirmOptions{},
+			userInput: bytes.NewBufferString("true\n"),
+			expect:    true,
+		},
+
+		// Default
+		{
+			opts: &ConfirmOptions{
+				Default: true,
+			},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    true,
+		},
+
+		{
+			opts:      &ConfirmOptions{},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    false,
+		},
+
+		// Loop
+		{
+			opts: &ConfirmOptions{
+				Loop: true,
+			},
+			userInput: bytes.NewBufferString("yep\nsure\ny\n"),
+			expect:    true,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: c.userInput,
+		}
+
+		ans, err := ui.Confirm("", c.opts)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if ans != c.expect {
+			t.Fatalf("#%d expect %v to be eq %v", i, ans, c.expect)
+		}
+	}
+}
+
+func TestConfirm_invalid(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("maybe\n"),
+	}
+
+	_, err := ui.Confirm("Continue?", &ConfirmOptions{})
+	if err != ErrNotYesNo {
+		t.Fatalf("expect %q to be eq %q", err, ErrNotYesNo)
+	}
+}
+
+func TestConfirm_hint(t *testing.T) {
+	cases := []struct {
+		def    bool
+		expect string
+	}{
+		{def: true, expect: "Continue? [Y/n]"},
+		{def: false, expect: "Continue? [y/N]"},
+	}
+
+	for i, c := range cases {
+		var out bytes.Buffer
+		ui := &UI{
+			Writer: &out,
+			Reader: bytes.NewBufferString("\n"),
+		}
+
+		if _, err := ui.Confirm("Continue?", &ConfirmOptions{Default: c.def}); err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if !strings.HasPrefix(out.String(), c.expect) {
+			t.Fatalf("#%d expect %q to have prefix %q", i, out.String(), c.expect)
+		}
+	}
+}
+
+func ExampleUI_Confirm() {
+	ui := &UI{
+		// In real world, Reader is os.Stdin and input comes
+		// from user actual input.
+		Reader: bytes.NewBufferString("\n"),
+		Writer: ioutil.Discard,
+	}
+
+	query := "Do you love golang?"
+	ans, _ := ui.Confirm(query, &ConfirmOptions{
+		Default: true,
+	})
+
+	fmt.Println(ans)
+	// Output: true
+}
@@ -0,0 +1,290 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Keys which are used only by the line editor.
+const (
+	keyCtrlA key = 1
+	keyCtrlB key = 2
+	keyCtrlD key = 4
+	keyCtrlE key = 5
+	keyCtrlF key = 6
+	keyCtrlK key = 11
+	keyCtrlW key = 23
+)
+
+// lineEditor edits a line on the terminal in raw mode like readline.
+// It supports the cursor movement (arrow keys, Home/End, Ctrl+A/E/B/F),
+// the deletion (Backspace, Delete, Ctrl+D) and the killing (Ctrl+W
+// to the start of the word, Ctrl+U to the start of the line and
+// Ctrl+K to the end of the line). The input is edited by the rune, so
+// a multi-byte character is never split. The runes which are removed
+// from the line are wiped so that the secret input does not remain in
+// the memory (see readSecret). If mask is true, each
+// character is displayed as maskVal. If meter is true, the strength of
+// the line is displayed after it. If crlf is true, '\n' which follows
+// '\r' is consumed as the part of the end of the line, which is sent
+// by the reader which is not a terminal (e.g., a file written on
+// Windows).
+type lineEditor struct {
+	w       io.Writer
+	mask    bool
+	maskVal string
+	meter   bool
+	crlf    bool
+
+	line []rune
+
+	// pos is the position of the cursor in line.
+	pos int
+
+	// col is the column of the cursor on the terminal from the
+	// start of the line.
+	col int
+}
+
+// readLine reads the keys and edits the line until Enter is pressed.
+// If Ctrl+C is pressed, it returns ErrInterrupted. If the reader
+// returns io.EOF, it returns the line which is already input, or
+// ErrEOF if nothing is input. Ctrl+D on the empty line is same as EOF.
+func (e *lineEditor) readLine(ctx context.Context, kr *keyReader) (string, error) {
+	if err := e.edit(ctx, kr); err != nil {
+		return "", err
+	}
+
+	return string(e.line), nil
+}
+
+// readSecret reads the line same as readLine, but returns it as the
+// bytes without converting it to string. The line is wiped.
+func (e *lineEditor) readSecret(ctx context.Context, kr *keyReader) ([]byte, error) {
+	defer func() {
+		wipeRunes(e.line)
+		e.line = nil
+	}()
+
+	if err := e.edit(ctx, kr); err != nil {
+		return nil, err
+	}
+
+	var n int
+	for _, r := range e.line {
+		n += utf8.RuneLen(r)
+	}
+
+	b := make([]byte, n)
+	var i int
+	for _, r := range e.line {
+		i += utf8.EncodeRune(b[i:], r)
+	}
+
+	return b, nil
+}
+
+// edit reads the keys and edits the line until Enter is pressed.
+func (e *lineEditor) edit(ctx context.Context, kr *keyReader) error {
+	for {
+		k, err := kr.readKey(ctx)
+		if err == io.EOF && len(e.line) > 0 {
+			break
+		}
+
+		if err == io.EOF || (k == keyCtrlD && len(e.line) == 0) {
+			fmt.Fprintf(e.w, "\n")
+			return ErrEOF
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if k == keyCR && e.crlf {
+			kr.in.skipLF()
+		}
+
+		if k == keyCR || k == keyLF {
+			break
+		}
+
+		if k == keyCtrlC {
+			return ErrInterrupted
+		}
+
+		e.handleKey(k)
+	}
+
+	// Erase the meter not to leave it on the screen
+	if e.meter {
+		e.meter = false
+		e.redraw()
+	}
+
+	fmt.Fprintf(e.w, "\n")
+	return nil
+}
+
+// handleKey edits the line by the key.
+func (e *lineEditor) handleKey(k key) {
+	switch k {
+	case keyLeft, keyCtrlB:
+		e.moveTo(e.pos - 1)
+	case keyRight, keyCtrlF:
+		e.moveTo(e.pos + 1)
+	case keyHome, keyCtrlA:
+		e.moveTo(0)
+	case keyEnd, keyCtrlE:
+		e.moveTo(len(e.line))
+	case keyBackspace, keyCtrlH:
+		if e.pos > 0 {
+			e.delete(e.pos-1, e.pos)
+		}
+	case keyDelete, keyCtrlD:
+		if e.pos < len(e.line) {
+			e.delete(e.pos, e.pos+1)
+		}
+	case keyCtrlW:
+		e.delete(e.wordStart(), e.pos)
+	case keyCtrlU:
+		e.delete(0, e.pos)
+	case keyCtrlK:
+		e.delete(e.pos, len(e.line))
+	default:
+		if k >= ' ' {
+			e.insert(rune(k))
+		}
+	}
+}
+
+// wordStart returns the position of the start of the word before the
+// cursor. The spaces after the word are included in the word.
+func (e *lineEditor) wordStart() int {
+	pos := e.pos
+	for pos > 0 && unicode.IsSpace(e.line[pos-1]) {
+		pos--
+	}
+
+	for pos > 0 && !unicode.IsSpace(e.line[pos-1]) {
+		pos--
+	}
+
+	return pos
+}
+
+// insert inserts r at the cursor and moves the cursor after it.
+func (e *lineEditor) insert(r rune) {
+	// Grow the line by hand to wipe the old one
+	if len(e.line) == cap(e.line) {
+		line := make([]rune, len(e.line), 2*cap(e.line)+16)
+		copy(line, e.line)
+		wipeRunes(e.line)
+		e.line = line
+	}
+
+	e.line = e.line[:len(e.line)+1]
+	copy(e.line[e.pos+1:], e.line[e.pos:])
+	e.line[e.pos] = r
+	e.pos++
+
+	// Just write it when it's appended, which is the most case
+	if e.pos == len(e.line) && !e.meter {
+		s := e.display(e.line[e.pos-1:])
+		e.col += e.width(e.line[e.pos-1:])
+		fmt.Fprint(e.w, s)
+		return
+	}
+
+	e.redraw()
+}
+
+// delete deletes the runes of line from start to end and moves
+// the cursor to start.
+func (e *lineEditor) delete(start, end int) {
+	if start >= end {
+		return
+	}
+
+	n := copy(e.line[start:], e.line[end:])
+	wipeRunes(e.line[start+n:])
+	e.line = e.line[:start+n]
+	e.pos = start
+	e.redraw()
+}
+
+// moveTo moves the cursor to pos.
+func (e *lineEditor) moveTo(pos int) {
+	if pos < 0 || len(e.line) < pos {
+		return
+	}
+
+	e.pos = pos
+	e.redraw()
+}
+
+// redraw redraws the line (and the meter) and puts the cursor at pos.
+func (e *lineEditor) redraw() {
+	var buf bytes.Buffer
+	if e.col > 0 {
+		buf.WriteString(fmt.Sprintf("\x1b[%dD", e.col))
+	}
+
+	buf.WriteString(e.display(e.line))
+
+	var meter string
+	if e.meter && len(e.line) > 0 {
+		meter = strengthMeter(e.line)
+	}
+	buf.WriteString(meter)
+	buf.WriteString(escEraseLine)
+
+	if n := e.width(e.line[e.pos:]) + stringWidth(meter); n > 0 {
+		buf.WriteString(fmt.Sprintf("\x1b[%dD", n))
+	}
+	e.col = e.width(e.line[:e.pos])
+
+	fmt.Fprint(e.w, buf.String())
+}
+
+// display returns the runes as they are displayed. If mask is true,
+// each rune is displayed as one maskVal regardless of its width.
+func (e *lineEditor) display(rs []rune) string {
+	if e.mask {
+		return strings.Repeat(e.maskVal, len(rs))
+	}
+
+	return string(rs)
+}
+
+// width returns the number of columns which the runes take on
+// the terminal. Wide characters and multi-byte maskVal are counted
+// by their width, not by the bytes.
+func (e *lineEditor) width(rs []rune) int {
+	return stringWidth(e.display(rs))
+}
+
+// The thresholds of the estimated entropy in bits for the strength
+// meter.
+const (
+	fairEntropy   = 36
+	strongEntropy = 60
+)
+
+// strengthMeter returns the meter of the strength of the line which is
+// displayed after it, e.g., "  [##-] fair".
+func strengthMeter(line []rune) string {
+	switch e := entropy(line); {
+	case e < fairEntropy:
+		return "  [#--] weak"
+	case e < strongEntropy:
+		return "  [##-] fair"
+	default:
+		return "  [###] strong"
+	}
+}
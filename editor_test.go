@@ -0,0 +1,274 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLineEditor(t *testing.T) {
+	cases := []struct {
+		userInput string
+		expect    string
+	}{
+		{
+			userInput: "taichi\r",
+			expect:    "taichi",
+		},
+
+		// Backspace
+		{
+			userInput: "taichii\x7f\r",
+			expect:    "taichi",
+		},
+
+		// Move the cursor and insert
+		{
+			userInput: "tachi\x1b[D\x1b[D\x1b[Di\r",
+			expect:    "taichi",
+		},
+
+		// Home and Delete
+		{
+			userInput: "xtaichi\x1b[H\x1b[3~\r",
+			expect:    "taichi",
+		},
+
+		// Ctrl+A and Ctrl+E
+		{
+			userInput: "aichi\x01t\x05!\r",
+			expect:    "taichi!",
+		},
+
+		// Ctrl+W deletes the word before the cursor
+		{
+			userInput: "taichi nakashima  \x17\r",
+			expect:    "taichi ",
+		},
+
+		// Ctrl+U deletes to the start of the line
+		{
+			userInput: "taichi nakashima\x1b[D\x1b[D\x15\r",
+			expect:    "ma",
+		},
+
+		// Ctrl+K deletes to the end of the line
+		{
+			userInput: "taichi nakashima\x01\x06\x06\x06\x06\x06\x06\x0b\r",
+			expect:    "taichi",
+		},
+
+		// Unknown keys are ignored
+		{
+			userInput: "tai\x1b[5Cchi\x1b[Z\r",
+			expect:    "taichi",
+		},
+
+		// Multi-byte characters
+		{
+			userInput: "中村\x1b[D太\r",
+			expect:    "中太村",
+		},
+
+		// EOF
+		{
+			userInput: "taichi",
+			expect:    "taichi",
+		},
+	}
+
+	for i, c := range cases {
+		e := &lineEditor{w: ioutil.Discard}
+		kr := &keyReader{in: newPump(bytes.NewBufferString(c.userInput))}
+
+		line, err := e.readLine(context.Background(), kr)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if line != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, line, c.expect)
+		}
+	}
+}
+
+func TestLineEditor_interrupted(t *testing.T) {
+	e := &lineEditor{w: ioutil.Discard}
+	kr := &keyReader{in: newPump(bytes.NewBufferString("taichi\x03"))}
+
+	if _, err := e.readLine(context.Background(), kr); err != ErrInterrupted {
+		t.Fatalf("expect %v to be eq %v", err, ErrInterrupted)
+	}
+}
+
+func TestLineEditor_mask(t *testing.T) {
+	var out bytes.Buffer
+	e := &lineEditor{w: &out, mask: true, maskVal: "*"}
+	kr := &keyReader{in: newPump(bytes.NewBufferString("passwd\x1b[D\x7f\r"))}
+
+	line, err := e.readLine(context.Background(), kr)
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if line != "passd" {
+		t.Fatalf("expect %q to be eq %q", line, "passd")
+	}
+
+	// The line is redrawn by the mask and the cursor is moved back
+	// before the last character.
+	expect := "******" + "\x1b[6D******" + escEraseLine + "\x1b[1D" +
+		"\x1b[5D*****" + escEraseLine + "\x1b[1D" + "\n"
+	if out.String() != expect {
+		t.Fatalf("expect %q to be eq %q", out.String(), expect)
+	}
+}
+
+func TestLineEditor_meter(t *testing.T) {
+	var out bytes.Buffer
+	e := &lineEditor{w: &out, mask: true, maskVal: "*", meter: true}
+	kr := &keyReader{in: newPump(bytes.NewBufferString("ab\r"))}
+
+	line, err := e.readLine(context.Background(), kr)
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if line != "ab" {
+		t.Fatalf("expect %q to be eq %q", line, "ab")
+	}
+
+	// The meter is redrawn after the mask on each key and the cursor
+	// is moved back before it. It's erased when Enter is pressed.
+	expect := "*  [#--] weak" + escEraseLine + "\x1b[12D" +
+		"\x1b[1D**  [#--] weak" + escEraseLine + "\x1b[12D" +
+		"\x1b[2D**" + escEraseLine + "\n"
+	if out.String() != expect {
+		t.Fatalf("expect %q to be eq %q", out.String(), expect)
+	}
+}
+
+func TestStrengthMeter(t *testing.T) {
+	cases := []struct {
+		line   string
+		expect string
+	}{
+		{line: "password", expect: "  [#--] weak"},
+		{line: "qwhtkzmxpd", expect: "  [##-] fair"},
+		{line: "Tr0ub4dor&3", expect: "  [###] strong"},
+	}
+
+	for i, c := range cases {
+		if meter := strengthMeter([]rune(c.line)); meter != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, meter, c.expect)
+		}
+	}
+}
+
+func TestLineEditor_utf8(t *testing.T) {
+	cases := []struct {
+		userInput string
+		maskVal   string
+		expect    string
+		expectOut string
+	}{
+		// One maskVal for each rune
+		{
+			userInput: "über\r",
+			maskVal:   "*",
+			expect:    "über",
+			expectOut: "****\n",
+		},
+
+		// Backspace deletes the whole rune
+		{
+			userInput: "パスワ\x7f\r",
+			maskVal:   "*",
+			expect:    "パス",
+			expectOut: "***" + "\x1b[3D**" + escEraseLine + "\n",
+		},
+
+		// Multi-byte maskVal is moved by its width
+		{
+			userInput: "pass\x1b[D\x1b[D\r",
+			maskVal:   "●",
+			expect:    "pass",
+			expectOut: "●●●●" + "\x1b[4D●●●●" + escEraseLine + "\x1b[1D" + "\x1b[3D●●●●" + escEraseLine + "\x1b[2D" + "\n",
+		},
+
+		// Wide maskVal takes two columns
+		{
+			userInput: "ab\x1b[D\r",
+			maskVal:   "＊",
+			expect:    "ab",
+			expectOut: "＊＊" + "\x1b[4D＊＊" + escEraseLine + "\x1b[2D" + "\n",
+		},
+	}
+
+	for i, c := range cases {
+		var out bytes.Buffer
+		e := &lineEditor{w: &out, mask: true, maskVal: c.maskVal}
+
+		// The terminal can return a multi-byte character split
+		kr := &keyReader{in: newPump(iotest.OneByteReader(bytes.NewBufferString(c.userInput)))}
+
+		line, err := e.readLine(context.Background(), kr)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if line != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, line, c.expect)
+		}
+
+		if out.String() != c.expectOut {
+			t.Fatalf("#%d expect %q to be eq %q", i, out.String(), c.expectOut)
+		}
+	}
+}
+
+func TestLineEditor_wide(t *testing.T) {
+	var out bytes.Buffer
+	e := &lineEditor{w: &out}
+	kr := &keyReader{in: newPump(bytes.NewBufferString("中村\x1b[D\r"))}
+
+	if _, err := e.readLine(context.Background(), kr); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	// The cursor is moved by two columns for the wide character
+	expect := "中村" + "\x1b[4D中村" + escEraseLine + "\x1b[2D" + "\n"
+	if out.String() != expect {
+		t.Fatalf("expect %q to be eq %q", out.String(), expect)
+	}
+}
+
+func TestLineEditor_EOF(t *testing.T) {
+	cases := []struct {
+		userInput string
+		expect    string
+		expectErr error
+	}{
+		{userInput: "", expectErr: ErrEOF},
+		{userInput: "\x04", expectErr: ErrEOF},
+
+		// Ctrl+D deletes the character on the non-empty line
+		{userInput: "taichii\x1b[D\x04\r", expect: "taichi"},
+	}
+
+	for i, c := range cases {
+		e := &lineEditor{w: ioutil.Discard}
+		kr := &keyReader{in: newPump(bytes.NewBufferString(c.userInput))}
+
+		line, err := e.readLine(context.Background(), kr)
+		if err != c.expectErr {
+			t.Fatalf("#%d expect %v to be eq %v", i, err, c.expectErr)
+		}
+
+		if line != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, line, c.expect)
+		}
+	}
+}
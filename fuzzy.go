@@ -0,0 +1,96 @@
+package input
+
+import (
+	"sort"
+	"unicode"
+)
+
+// Scores of fuzzyMatch.
+const (
+	scoreMatch       = 1
+	scoreConsecutive = 5
+	scoreWordStart   = 3
+)
+
+// fuzzyMatch reports whether all runes of pattern appear in s in the
+// same order. The case is ignored. It also returns the score of
+// the match (higher is better) and the positions (index of rune) of
+// the matched runes in s. Consecutive matches and matches at the
+// start of words score higher.
+func fuzzyMatch(pattern, s string) (int, []int, bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	var score int
+	var positions []int
+	var prev rune
+	j := 0
+	for i, r := range []rune(s) {
+		if j < len(p) && unicode.ToLower(r) == unicode.ToLower(p[j]) {
+			score += scoreMatch
+
+			if n := len(positions); n > 0 && positions[n-1] == i-1 {
+				score += scoreConsecutive
+			}
+
+			if i == 0 || isWordStart(prev, r) {
+				score += scoreWordStart
+			}
+
+			positions = append(positions, i)
+			j++
+		}
+		prev = r
+	}
+
+	if j < len(p) {
+		return 0, nil, false
+	}
+
+	return score, positions, true
+}
+
+// isWordStart reports whether r starts a new word after prev,
+// e.g., after separators or at the lower to upper case change.
+func isWordStart(prev, r rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+
+	return unicode.IsLower(prev) && unicode.IsUpper(r)
+}
+
+// filterItems returns the indexes of items which match the pattern
+// and the positions of matched runes of each item. Items are sorted
+// by the score, and the items which have same score keep the
+// original order.
+func filterItems(pattern string, items []string) ([]int, [][]int) {
+	type match struct {
+		index     int
+		score     int
+		positions []int
+	}
+
+	var matches []match
+	for i, item := range items {
+		score, positions, ok := fuzzyMatch(pattern, item)
+		if ok {
+			matches = append(matches, match{index: i, score: score, positions: positions})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	indexes := make([]int, 0, len(matches))
+	positions := make([][]int, 0, len(matches))
+	for _, m := range matches {
+		indexes = append(indexes, m.index)
+		positions = append(positions, m.positions)
+	}
+
+	return indexes, positions
+}
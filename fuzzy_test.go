@@ -0,0 +1,59 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	cases := []struct {
+		pattern   string
+		s         string
+		positions []int
+		ok        bool
+	}{
+		{pattern: "", s: "staging", positions: nil, ok: true},
+		{pattern: "stg", s: "staging", positions: []int{0, 1, 3}, ok: true},
+		{pattern: "STG", s: "staging", positions: []int{0, 1, 3}, ok: true},
+		{pattern: "ü", s: "Münch", positions: []int{1}, ok: true},
+		{pattern: "gts", s: "staging", positions: nil, ok: false},
+	}
+
+	for i, c := range cases {
+		_, positions, ok := fuzzyMatch(c.pattern, c.s)
+		if ok != c.ok {
+			t.Fatalf("#%d expect %v to be eq %v", i, ok, c.ok)
+		}
+
+		if !reflect.DeepEqual(positions, c.positions) {
+			t.Fatalf("#%d expect %v to be eq %v", i, positions, c.positions)
+		}
+	}
+}
+
+func TestFilterItems(t *testing.T) {
+	items := []string{"my-prod-east", "production", "dev", "prod"}
+
+	indexes, positions := filterItems("prod", items)
+
+	// Consecutive matches at the start of the word come first,
+	// same score keeps the original order.
+	expect := []int{0, 1, 3}
+	if !reflect.DeepEqual(indexes, expect) {
+		t.Fatalf("expect %v to be eq %v", indexes, expect)
+	}
+
+	if len(positions) != len(indexes) {
+		t.Fatalf("expect %d positions to be returned", len(indexes))
+	}
+}
+
+func TestFilterItems_score(t *testing.T) {
+	items := []string{"pxrxoxd", "prod"}
+
+	indexes, _ := filterItems("prod", items)
+	expect := []int{1, 0}
+	if !reflect.DeepEqual(indexes, expect) {
+		t.Fatalf("expect %v to be eq %v", indexes, expect)
+	}
+}
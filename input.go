@@ -16,8 +16,8 @@ Package input reads user input at the console. http://github.com/tcnksm/go-input
 package input
 
 import (
-	"bufio"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"sync"
@@ -36,10 +36,20 @@ var (
 var (
 	// Errs are error returned by input functions.
 	// It's useful for handling error from outside of input functions.
-	ErrEmpty       = errors.New("default value is not provided but input is empty")
-	ErrNotNumber   = errors.New("input must be number")
-	ErrOutOfRange  = errors.New("input is out of range")
-	ErrInterrupted = errors.New("interrupted")
+	ErrEmpty            = errors.New("default value is not provided but input is empty")
+	ErrNotNumber        = errors.New("input must be number")
+	ErrOutOfRange       = errors.New("input is out of range")
+	ErrNotYesNo         = errors.New("input must be yes or no")
+	ErrSelectionCount   = errors.New("number of selected items is out of range")
+	ErrDisabled         = errors.New("selected item is disabled")
+	ErrInterrupted      = errors.New("interrupted")
+	ErrNoTTY            = errors.New("no controlling terminal")
+	ErrNonInteractive   = errors.New("input is not available in non-interactive mode")
+	ErrEOF              = errors.New("reached the end of the input")
+	ErrTooManyAttempts  = errors.New("too many attempts")
+	ErrBack             = errors.New("back to the previous question")
+	ErrPasswordPolicy   = errors.New("password does not satisfy the policy")
+	ErrPasswordMismatch = errors.New("passwords do not match")
 )
 
 // UI is user-interface of input and output.
@@ -51,11 +61,51 @@ type UI struct {
 	// Reader is source of input. By default, it's os.Stdin.
 	Reader io.Reader
 
+	// Terminal controls the terminal connected to Reader, e.g., to
+	// read the masked input. By default, if Reader is *os.File, it's
+	// the terminal connected to the file (if any). See Terminal.
+	Terminal Terminal
+
+	// TTY makes UI read from and write to the controlling terminal
+	// (/dev/tty, or the console on windows) when Reader or Writer is
+	// not a terminal, e.g., when stdin is piped. If there is no
+	// controlling terminal, the prompts return ErrNoTTY.
+	TTY bool
+
+	// NonInteractive makes the prompts use the default values without
+	// reading the input. If the prompt has no default value, it returns
+	// NonInteractiveError, which matches ErrNonInteractive.
+	NonInteractive bool
+
+	// DetectNonInteractive makes UI non-interactive when the CI
+	// environment variable is set or Reader is not a terminal (e.g.,
+	// an open but silent pipe), same as NonInteractive.
+	DetectNonInteractive bool
+
+	// Resolvers resolve the answers of the prompts which have
+	// Options.Key before asking the user. They are consulted in
+	// order and the first answer is used. The answer is validated
+	// same as the input. If it's invalid, the user is asked in Loop
+	// or the error is returned.
+	Resolvers []Resolver
+
+	// Recorder records the answers of the prompts which have
+	// Options.Key, e.g., to write the answers file. See Recorder.
+	Recorder *Recorder
+
 	// mask is option for read function
 	mask    bool
 	maskVal string
+	meter   bool
+	crlf    bool
+
+	// in reads Reader. It's shared by the prompts so that the input
+	// is not lost when the prompt is interrupted.
+	in *pump
 
-	bReader *bufio.Reader
+	// err is the error which occurred while setting the default
+	// value, which is returned by the prompts.
+	err error
 
 	once sync.Once
 }
@@ -68,6 +118,13 @@ func DefaultUI() *UI {
 	}
 }
 
+// setup sets the default value for UI struct once and returns the
+// error which occurred while setting it.
+func (i *UI) setup() error {
+	i.once.Do(i.setDefault)
+	return i.err
+}
+
 // setDefault sets the default value for UI struct.
 func (i *UI) setDefault() {
 	// Set the default writer & reader if not provided
@@ -79,8 +136,12 @@ func (i *UI) setDefault() {
 		i.Reader = defaultReader
 	}
 
-	if i.bReader == nil {
-		i.bReader = bufio.NewReader(i.Reader)
+	if i.TTY {
+		i.err = i.useTTY()
+	}
+
+	if i.in == nil {
+		i.in = newPump(i.Reader)
 	}
 }
 
@@ -90,8 +151,18 @@ func (i *UI) setDefault() {
 // 'Y' or 'n' when asking yes or no question.
 type ValidateFunc func(string) error
 
+// ValidateAttemptFunc is function to validate the user input same as
+// ValidateFunc, but it also receives the number of the attempt, which
+// starts from 1 and is incremented every time the input is rejected
+// in Loop. It's useful to give the user more help on later attempts.
+type ValidateAttemptFunc func(input string, attempt int) error
+
 // Options is structure contains option for input functions.
 type Options struct {
+	// Key is the stable key of the prompt which is used to resolve
+	// the answer by UI.Resolvers, e.g., the name of the flag.
+	Key string
+
 	// Default is the default value which is used when no thing
 	// is input.
 	Default string
@@ -118,13 +189,56 @@ type Options struct {
 	// MaskDefault hides default value. By default, MaskVal is asterisk(*).
 	MaskDefault bool
 
+	// StrengthMeter displays the estimated strength of the masked
+	// input (weak, fair or strong) next to the mask, which is updated
+	// on each keystroke. It works only when Reader is a terminal.
+	StrengthMeter bool
+
 	// MaskVal is a value which is used for masking user input.
-	// By default, MaskVal is asterisk(*).
+	// Each character is displayed as one MaskVal, and it can be
+	// a multi-byte string (e.g., "●"). By default, MaskVal is
+	// asterisk(*).
 	MaskVal string
 
 	// ValidateFunc is function to do extra validation of user
 	// input string. By default, it does nothing (just returns nil).
 	ValidateFunc ValidateFunc
+
+	// ValidateAttemptFunc is called after ValidateFunc with
+	// the number of the attempt. See ValidateAttemptFunc.
+	ValidateAttemptFunc ValidateAttemptFunc
+
+	// MaxAttempts is the maximum number of the attempts in Loop.
+	// When the input is rejected MaxAttempts times, it stops asking
+	// and returns TooManyAttemptsError. By default, it's unlimited.
+	MaxAttempts int
+
+	// BackCommand is the input which makes the prompt return ErrBack,
+	// e.g., "<" to go back to the previous question (see Wizard). In
+	// interactive mode of Select, the left arrow key does the same.
+	// By default, it's disabled.
+	BackCommand string
+
+	// DefaultOnTimeout returns Default instead of the error when the
+	// deadline of the context (e.g., AskContext) is exceeded.
+	DefaultOnTimeout bool
+
+	// Interactive lets Select ask the user to select an item by
+	// moving the cursor with arrow keys (or j/k) and Enter when
+	// Reader is a terminal. Otherwise, it asks by the number.
+	Interactive bool
+
+	// Filter lets the user narrow the items by typing in interactive
+	// mode of Select. The items are matched fuzzily and only arrow keys
+	// move the cursor.
+	Filter bool
+
+	// PageSize is the number of items which Select displays at once.
+	// In interactive mode, the items are scrolled with the cursor and
+	// by default it fits the height of the terminal. Otherwise, the
+	// pages are turned by entering n or p. By default, all items are
+	// displayed.
+	PageSize int
 }
 
 // validateFunc returns ValidateFunc. If it's specified by
@@ -137,6 +251,20 @@ func (o *Options) validateFunc() ValidateFunc {
 	return o.ValidateFunc
 }
 
+// validate validates the input of the given attempt by ValidateFunc
+// and ValidateAttemptFunc.
+func (o *Options) validate(input string, attempt int) error {
+	if err := o.validateFunc()(input); err != nil {
+		return err
+	}
+
+	if o.ValidateAttemptFunc != nil {
+		return o.ValidateAttemptFunc(input, attempt)
+	}
+
+	return nil
+}
+
 // defaultValidateFunc is default ValidateFunc which does
 // nothing.
 func defaultValidateFunc(input string) error {
@@ -167,14 +295,30 @@ func (o *Options) readOpts() *readOptions {
 	return &readOptions{
 		mask:    mask,
 		maskVal: maskVal,
+		meter:   mask && o.StrengthMeter,
 	}
 }
 
+// hideMaskedDefault hides Default of the masked or hidden prompt not
+// to reveal any part of it, which MaskDefault does, and returns the
+// hint which shows that it's used on empty input.
+func (o *Options) hideMaskedDefault() string {
+	if o.Default == "" || !(o.Mask || o.Hide) {
+		return ""
+	}
+
+	o.HideDefault = true
+	return fmt.Sprintf(" (Default is %s)", maskString(""))
+}
+
 // maskString is used to mask string which should not be displayed.
+// The first 3 characters are kept, which are counted by the rune
+// so that a multi-byte character is not split.
 func maskString(s string) string {
-	if len(s) < 3 {
+	rs := []rune(s)
+	if len(rs) < 3 {
 		return "*******"
 	}
 
-	return s[:3] + "****"
+	return string(rs[:3]) + "****"
 }
@@ -35,3 +35,21 @@ func ExampleValidateFunc() {
 	fmt.Println(ans)
 	// Output: Y
 }
+
+func TestMaskString(t *testing.T) {
+	cases := []struct {
+		in     string
+		expect string
+	}{
+		{in: "", expect: "*******"},
+		{in: "ab", expect: "*******"},
+		{in: "passw0rd", expect: "pas****"},
+		{in: "パスワード", expect: "パスワ****"},
+	}
+
+	for i, c := range cases {
+		if got := maskString(c.in); got != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, got, c.expect)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package input
+
+import (
+	"context"
+	"os"
+	"unicode/utf8"
+)
+
+// key is a key pressed by the user in raw mode. Printable characters
+// and control characters are represented by its rune and special keys
+// (e.g., arrow keys) are represented by negative values.
+type key rune
+
+const (
+	keyUnknown key = -(iota + 1)
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyHome
+	keyEnd
+	keyDelete
+	keyPageUp
+	keyPageDown
+)
+
+const (
+	keyCtrlC     key = 3
+	keyCtrlH     key = 8
+	keyCtrlN     key = 14
+	keyCtrlP     key = 16
+	keyCtrlU     key = 21
+	keyLF        key = '\n'
+	keyCR        key = '\r'
+	keyEscape    key = 27
+	keyBackspace key = 127
+)
+
+// keyReader reads keys from the terminal in raw mode.
+type keyReader struct {
+	in *pump
+
+	// sigCh receives SIGINT. It can be nil because Ctrl+C is read
+	// as the key in raw mode.
+	sigCh <-chan os.Signal
+}
+
+// readByte reads a single byte.
+func (k *keyReader) readByte(ctx context.Context) (byte, error) {
+	return k.in.readByte(ctx, k.sigCh)
+}
+
+// readKey reads a single key. It decodes the escape sequences of
+// special keys and UTF-8 encoded characters. If the context is done
+// before the key is read, it returns ctx.Err().
+func (k *keyReader) readKey(ctx context.Context) (key, error) {
+	b, err := k.readByte(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case b == byte(keyEscape):
+		return k.readEscape(ctx)
+	case b < utf8.RuneSelf:
+		return key(b), nil
+	}
+
+	// Read the rest of the multi-byte character
+	var buf [utf8.UTFMax]byte
+	defer wipeBytes(buf[:])
+
+	buf[0] = b
+	n := 1
+	for !utf8.FullRune(buf[:n]) {
+		b, err := k.readByte(ctx)
+		if err != nil {
+			return 0, err
+		}
+		buf[n] = b
+		n++
+	}
+
+	r, _ := utf8.DecodeRune(buf[:n])
+	if r == utf8.RuneError {
+		return keyUnknown, nil
+	}
+
+	return key(r), nil
+}
+
+// readEscape reads the escape sequence which follows ESC.
+// e.g., "ESC [ A" is the up arrow key.
+func (k *keyReader) readEscape(ctx context.Context) (key, error) {
+	b, err := k.readByte(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if b != '[' && b != 'O' {
+		return keyUnknown, nil
+	}
+
+	// Read the parameter bytes until the final byte
+	var params []byte
+	for {
+		b, err = k.readByte(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		if 0x40 <= b && b <= 0x7e {
+			break
+		}
+		params = append(params, b)
+	}
+
+	switch b {
+	case 'A':
+		return keyUp, nil
+	case 'B':
+		return keyDown, nil
+	case 'C':
+		return keyRight, nil
+	case 'D':
+		return keyLeft, nil
+	case 'H':
+		return keyHome, nil
+	case 'F':
+		return keyEnd, nil
+	case '~':
+		switch string(params) {
+		case "1", "7":
+			return keyHome, nil
+		case "4", "8":
+			return keyEnd, nil
+		case "3":
+			return keyDelete, nil
+		case "5":
+			return keyPageUp, nil
+		case "6":
+			return keyPageDown, nil
+		}
+	}
+
+	return keyUnknown, nil
+}
@@ -0,0 +1,439 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Escape sequences to control the terminal.
+const (
+	escHideCursor  = "\x1b[?25l"
+	escShowCursor  = "\x1b[?25h"
+	escReverse     = "\x1b[7m"
+	escBold        = "\x1b[1m"
+	escDim         = "\x1b[2m"
+	escNormal      = "\x1b[22m"
+	escUnderline   = "\x1b[4m"
+	escNoUnderline = "\x1b[24m"
+	escReset       = "\x1b[0m"
+	escEraseDown   = "\x1b[J"
+	escEraseLine   = "\x1b[K"
+)
+
+// Hints which are shown below the items of the menu.
+const (
+	menuHint       = "(Use arrow keys or j/k to move, Enter to select)"
+	menuFilterHint = "(Type to filter, use arrow keys to move, Enter to select)"
+)
+
+// menu is the list of items which the user selects by moving the
+// cursor. It's used by Select in interactive mode.
+type menu struct {
+	query string
+	items []Item
+
+	// filterable enables to narrow the items by typing.
+	filterable bool
+	filter     []rune
+
+	// visible is the indexes of items which match the filter and
+	// positions is the positions of the matched runes of them.
+	visible   []int
+	positions [][]int
+
+	// cursor is the position in visible.
+	cursor int
+
+	// pageSize is the number of items displayed at once and offset
+	// is the position in visible of the first displayed item. If
+	// pageSize is 0, all items are displayed.
+	pageSize int
+	offset   int
+
+	// message is shown below the items, e.g., the validation error.
+	message string
+
+	// lines is the number of lines drawn by the last render.
+	lines int
+}
+
+// newMenu returns the menu whose cursor is on the item of the given
+// index. If index is negative, it's on the first item.
+func newMenu(query string, items []Item, index int, filterable bool) *menu {
+	m := &menu{
+		query:      query,
+		items:      items,
+		filterable: filterable,
+	}
+	m.updateFilter()
+
+	if index >= 0 {
+		m.cursor = index
+	}
+
+	// The cursor must not be on a heading
+	m.move(0)
+
+	return m
+}
+
+// setPageSize sets the number of items displayed at once.
+func (m *menu) setPageSize(n int) {
+	m.pageSize = n
+	m.scroll()
+}
+
+// selected returns the index of the item under the cursor. If no
+// item can be selected (e.g., no item matches the filter), it
+// returns -1.
+func (m *menu) selected() int {
+	if len(m.visible) == 0 || m.items[m.visible[m.cursor]].Heading {
+		return -1
+	}
+
+	return m.visible[m.cursor]
+}
+
+// move moves the cursor by n items. The cursor stops at the first
+// and the last item and skips the headings.
+func (m *menu) move(n int) {
+	cursor := m.cursor + n
+	if cursor >= len(m.visible) {
+		cursor = len(m.visible) - 1
+	}
+
+	if cursor < 0 {
+		cursor = 0
+	}
+
+	// Skip the headings in the direction of the move. If there
+	// is no item to select, try the opposite direction.
+	dir := 1
+	if n < 0 {
+		dir = -1
+	}
+
+	if c := m.nextItem(cursor, dir); c >= 0 {
+		cursor = c
+	} else if c := m.nextItem(cursor, -dir); c >= 0 {
+		cursor = c
+	}
+
+	m.cursor = cursor
+	m.scroll()
+}
+
+// nextItem returns the position in visible of the first item which
+// is not a heading from pos in the direction dir (1 or -1). If no
+// such item exists, it returns -1.
+func (m *menu) nextItem(pos, dir int) int {
+	for ; 0 <= pos && pos < len(m.visible); pos += dir {
+		if !m.items[m.visible[pos]].Heading {
+			return pos
+		}
+	}
+
+	return -1
+}
+
+// scroll scrolls the displayed items so that the cursor is on them.
+func (m *menu) scroll() {
+	if m.pageSize <= 0 {
+		return
+	}
+
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+
+	if m.cursor >= m.offset+m.pageSize {
+		m.offset = m.cursor - m.pageSize + 1
+	}
+}
+
+// page returns the range of visible which is displayed.
+func (m *menu) page() (int, int) {
+	if m.pageSize <= 0 || len(m.visible) <= m.pageSize {
+		return 0, len(m.visible)
+	}
+
+	end := m.offset + m.pageSize
+	if end > len(m.visible) {
+		end = len(m.visible)
+	}
+
+	return m.offset, end
+}
+
+// updateFilter narrows the items by the filter and moves the
+// cursor to the best matched item.
+func (m *menu) updateFilter() {
+	visible, positions := filterItems(string(m.filter), labels(m.items))
+
+	// Headings are displayed only when the items are not filtered
+	m.visible, m.positions = visible[:0], positions[:0]
+	for i, index := range visible {
+		if len(m.filter) == 0 || !m.items[index].Heading {
+			m.visible = append(m.visible, index)
+			m.positions = append(m.positions, positions[i])
+		}
+	}
+
+	m.cursor, m.offset = 0, 0
+	m.move(0)
+}
+
+// render draws the menu. It erases the previously drawn menu before
+// drawing the new one.
+func (m *menu) render(w io.Writer) {
+	var buf bytes.Buffer
+	m.erase(&buf)
+
+	hint := menuHint
+	if m.filterable {
+		hint = menuFilterHint
+		m.writeLine(&buf, fmt.Sprintf("%s %s", m.query, string(m.filter)))
+	} else {
+		m.writeLine(&buf, m.query)
+	}
+
+	start, end := m.page()
+	width := labelWidth(m.items, m.visible[start:end])
+	for i := start; i < end; i++ {
+		it := m.items[m.visible[i]]
+		if it.Heading {
+			m.writeLine(&buf, escBold+it.Label+escNormal)
+			continue
+		}
+
+		// The escape sequences added by highlight take no width
+		label := highlight(it.Label, m.positions[i])
+		line := formatItem(Item{Label: label, Description: it.Description}, width+stringWidth(label)-stringWidth(it.Label), true)
+		if it.Disabled {
+			line = escDim + line + escNormal
+		}
+
+		if i == m.cursor {
+			m.writeLine(&buf, "> "+escReverse+line+escReset)
+			continue
+		}
+		m.writeLine(&buf, "  "+line)
+	}
+
+	if len(m.visible) == 0 {
+		m.writeLine(&buf, "  No item matches")
+	}
+
+	if end-start < len(m.visible) {
+		m.writeLine(&buf, fmt.Sprintf("  (%d-%d of %d)", start+1, end, len(m.visible)))
+	}
+
+	if m.message != "" {
+		m.writeLine(&buf, m.message)
+	}
+	m.writeLine(&buf, hint)
+
+	fmt.Fprint(w, buf.String())
+}
+
+// highlight underlines the runes of s at the given positions.
+func highlight(s string, positions []int) string {
+	if len(positions) == 0 {
+		return s
+	}
+
+	var buf bytes.Buffer
+	j := 0
+	for i, r := range []rune(s) {
+		if j < len(positions) && positions[j] == i {
+			buf.WriteString(escUnderline + string(r) + escNoUnderline)
+			j++
+			continue
+		}
+		buf.WriteRune(r)
+	}
+
+	return buf.String()
+}
+
+// writeLine writes s as line(s). In raw mode, carriage return is
+// required to move the cursor to the beginning of the next line.
+func (m *menu) writeLine(buf *bytes.Buffer, s string) {
+	for _, line := range strings.Split(s, "\n") {
+		buf.WriteString(line + "\r\n")
+		m.lines++
+	}
+}
+
+// erase erases the previously drawn menu. The cursor is moved
+// to the line where the menu started.
+func (m *menu) erase(buf *bytes.Buffer) {
+	if m.lines > 0 {
+		buf.WriteString(fmt.Sprintf("\x1b[%dA", m.lines))
+		buf.WriteString(escEraseDown)
+	}
+	m.lines = 0
+}
+
+// clear erases the menu.
+func (m *menu) clear(w io.Writer) {
+	var buf bytes.Buffer
+	m.erase(&buf)
+	fmt.Fprint(w, buf.String())
+}
+
+// finish erases the menu and displays the query with the selected item.
+func (m *menu) finish(w io.Writer) {
+	var buf bytes.Buffer
+	m.erase(&buf)
+	m.writeLine(&buf, fmt.Sprintf("%s %s", m.query, m.items[m.selected()].Label))
+	fmt.Fprint(w, buf.String())
+}
+
+// selectInteractive asks the user to select an item from the given
+// list by moving the cursor on the terminal.
+func (i *UI) selectInteractive(ctx context.Context, t Terminal, query string, list []Item, defaultIndex int, opts *Options) (int, error) {
+	if err := t.MakeRaw(); err != nil {
+		return -1, err
+	}
+	defer t.Restore()
+
+	m := newMenu(query, list, defaultIndex, opts.Filter)
+
+	// Fit the menu to the terminal if the page size is not specified
+	pageSize := opts.PageSize
+	if s, ok := t.(sizer); ok && pageSize <= 0 {
+		if _, height, err := s.Size(); err == nil {
+			// Leave the lines for the query, the message, the range
+			// of the displayed items and the hint.
+			pageSize = height - 4
+		}
+	}
+
+	if pageSize < 1 {
+		pageSize = 1
+	}
+	m.setPageSize(pageSize)
+
+	return i.runMenu(ctx, &keyReader{in: i.in}, m, opts)
+}
+
+// runMenu draws the menu and moves its cursor by the keys until
+// an item is selected. It returns the index of the selected item.
+// If the context is done before that, it returns ctx.Err().
+func (i *UI) runMenu(ctx context.Context, kr *keyReader, m *menu, opts *Options) (int, error) {
+	fmt.Fprint(i.Writer, escHideCursor)
+	defer fmt.Fprint(i.Writer, escShowCursor)
+
+	// attempt is incremented when the selection is rejected
+	attempt := 1
+	for {
+		m.render(i.Writer)
+
+		k, err := kr.readKey(ctx)
+		if err != nil && err == ctx.Err() {
+			m.clear(i.Writer)
+			return -1, err
+		}
+
+		if err == io.EOF {
+			m.clear(i.Writer)
+			return -1, ErrEOF
+		}
+
+		if err != nil {
+			m.clear(i.Writer)
+			return -1, fmt.Errorf("failed to read the input: %s", err)
+		}
+
+		switch k {
+		case keyUp, keyCtrlP:
+			m.move(-1)
+		case keyDown, keyCtrlN:
+			m.move(1)
+		case keyHome:
+			m.move(-len(m.items))
+		case keyEnd:
+			m.move(len(m.items))
+		case keyPageUp:
+			m.move(-m.pageSize)
+		case keyPageDown:
+			m.move(m.pageSize)
+		case 'k', 'j':
+			if m.filterable {
+				m.filter = append(m.filter, rune(k))
+				m.updateFilter()
+			} else if k == 'k' {
+				m.move(-1)
+			} else {
+				m.move(1)
+			}
+		case keyBackspace, keyCtrlH:
+			if n := len(m.filter); n > 0 {
+				m.filter = m.filter[:n-1]
+				m.updateFilter()
+			}
+		case keyCtrlU:
+			if len(m.filter) > 0 {
+				m.filter = m.filter[:0]
+				m.updateFilter()
+			}
+		case keyCtrlC:
+			m.clear(i.Writer)
+			return -1, ErrInterrupted
+		case keyCtrlD:
+			// Same as EOF like the line editor
+			if len(m.filter) == 0 {
+				m.clear(i.Writer)
+				return -1, ErrEOF
+			}
+		case keyLeft:
+			if opts.BackCommand != "" {
+				m.clear(i.Writer)
+				return -1, ErrBack
+			}
+		case keyCR, keyLF:
+			n := m.selected()
+			if n < 0 {
+				continue
+			}
+
+			// Check the item can be selected
+			if item := m.items[n]; item.Disabled {
+				if err := opts.giveUp(attempt, ErrDisabled); err != nil {
+					m.clear(i.Writer)
+					return -1, err
+				}
+
+				attempt++
+				m.message = disabledMessage(item)
+				continue
+			}
+
+			// validate input by custom function
+			numbers, _ := numberItems(m.items)
+			if err := opts.validate(strconv.Itoa(numbers[n]), attempt); err != nil {
+				if err := opts.giveUp(attempt, err); err != nil {
+					m.clear(i.Writer)
+					return -1, err
+				}
+
+				attempt++
+				m.message = fmt.Sprintf("Failed to validate input string: %s", err)
+				continue
+			}
+
+			m.finish(i.Writer)
+			return n, nil
+		default:
+			if m.filterable && k >= ' ' {
+				m.filter = append(m.filter, rune(k))
+				m.updateFilter()
+			}
+		}
+	}
+}
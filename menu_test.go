@@ -0,0 +1,418 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunMenu(t *testing.T) {
+	cases := []struct {
+		cursor    int
+		opts      *Options
+		userInput string
+		expect    int
+	}{
+		{
+			opts:      &Options{},
+			userInput: "\r",
+			expect:    0,
+		},
+
+		{
+			opts:      &Options{},
+			userInput: "\x1b[B\x1b[B\r",
+			expect:    2,
+		},
+
+		{
+			opts:      &Options{},
+			userInput: "jjjjk\r",
+			expect:    1,
+		},
+
+		// Default
+		{
+			cursor:    2,
+			opts:      &Options{},
+			userInput: "\n",
+			expect:    2,
+		},
+
+		{
+			cursor:    2,
+			opts:      &Options{},
+			userInput: "\x1b[A\x1bOA\x1b[F\x1b[1~\r",
+			expect:    0,
+		},
+
+		// Loop
+		{
+			opts: &Options{
+				Loop: true,
+				ValidateFunc: func(s string) error {
+					if s != "3" {
+						return fmt.Errorf("choose 3")
+					}
+					return nil
+				},
+			},
+			userInput: "\rj\rj\r",
+			expect:    2,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+		}
+
+		m := newMenu("", newItems([]string{"A", "B", "C"}), c.cursor, false)
+
+		n, err := ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString(c.userInput))}, m, c.opts)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if n != c.expect {
+			t.Fatalf("#%d expect %d to be eq %d", i, n, c.expect)
+		}
+	}
+}
+
+func TestRunMenu_interrupted(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+	}
+
+	m := newMenu("", newItems([]string{"A", "B", "C"}), -1, false)
+
+	_, err := ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString("j\x03"))}, m, &Options{})
+	if err != ErrInterrupted {
+		t.Fatalf("expect %q to be eq %q", err, ErrInterrupted)
+	}
+}
+
+func TestRunMenu_back(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+	}
+
+	// The left arrow key is ignored without BackCommand
+	m := newMenu("", newItems([]string{"A", "B", "C"}), -1, false)
+	n, err := ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString("j\x1b[D\r"))}, m, &Options{})
+	if err != nil || n != 1 {
+		t.Fatalf("expect (%d, %v) to be eq (%d, %v)", n, err, 1, nil)
+	}
+
+	m = newMenu("", newItems([]string{"A", "B", "C"}), -1, false)
+	_, err = ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString("j\x1b[D\r"))}, m, &Options{BackCommand: "<"})
+	if err != ErrBack {
+		t.Fatalf("expect %v to be eq %v", err, ErrBack)
+	}
+}
+
+func TestRunMenu_eof(t *testing.T) {
+	cases := []struct {
+		filter    bool
+		userInput string
+		expect    int
+		expectErr error
+	}{
+		{
+			filter:    false,
+			userInput: "j\x04",
+			expect:    -1,
+			expectErr: ErrEOF,
+		},
+
+		// Ctrl+D is ignored while filtering
+		{
+			filter:    true,
+			userInput: "B\x04\r",
+			expect:    1,
+		},
+	}
+
+	for i, c := range cases {
+		// The reader does not reach EOF
+		r, w := io.Pipe()
+		go w.Write([]byte(c.userInput))
+
+		var out bytes.Buffer
+		ui := &UI{
+			Writer: &out,
+		}
+
+		// Fail instead of waiting for the input forever
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+		m := newMenu("Which?", newItems([]string{"A", "B", "C"}), -1, c.filter)
+		n, err := ui.runMenu(ctx, &keyReader{in: newPump(r)}, m, &Options{})
+		cancel()
+		w.Close()
+
+		if n != c.expect || err != c.expectErr {
+			t.Fatalf("#%d expect (%d, %v) to be eq (%d, %v)", i, n, err, c.expect, c.expectErr)
+		}
+
+		// The menu is erased
+		if c.expectErr != nil && !strings.HasSuffix(out.String(), escEraseDown+escShowCursor) {
+			t.Fatalf("#%d expect the menu to be erased: %q", i, out.String())
+		}
+	}
+}
+
+func TestRunMenu_canceled(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	m := newMenu("Which?", newItems([]string{"A", "B", "C"}), -1, false)
+	_, err := ui.runMenu(ctx, &keyReader{in: newPump(r)}, m, &Options{})
+	if err != context.Canceled {
+		t.Fatalf("expect %q to be eq %q", err, context.Canceled)
+	}
+
+	// The menu is erased
+	if !strings.HasSuffix(out.String(), "\x1b[5A"+escEraseDown+escShowCursor) {
+		t.Fatalf("expect the menu to be erased: %q", out.String())
+	}
+}
+
+func TestRunMenu_output(t *testing.T) {
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+	}
+
+	m := newMenu("Which?", newItems([]string{"A", "B", "C"}), -1, false)
+
+	if _, err := ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString("j\r"))}, m, &Options{}); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	// The first render draws the query, 3 items and the hint
+	// and the cursor is on the first item.
+	first := "Which?\r\n> " + escReverse + "A" + escReset + "\r\n  B\r\n  C\r\n" + menuHint + "\r\n"
+	if !strings.Contains(out.String(), first) {
+		t.Fatalf("expect %q to contain %q", out.String(), first)
+	}
+
+	// The menu is erased and the selected item is displayed at last.
+	last := "\x1b[5A" + escEraseDown + "Which? B\r\n" + escShowCursor
+	if !strings.HasSuffix(out.String(), last) {
+		t.Fatalf("expect %q to have suffix %q", out.String(), last)
+	}
+}
+
+func TestRunMenu_filter(t *testing.T) {
+	cases := []struct {
+		userInput string
+		expect    int
+	}{
+		{
+			userInput: "prd\r",
+			expect:    2,
+		},
+
+		// j and k are typed as the filter
+		{
+			userInput: "jk\x1b[B\r",
+			expect:    3,
+		},
+
+		// Backspace
+		{
+			userInput: "prdx\x7f\x7f\x7f\x7fstg\r",
+			expect:    1,
+		},
+
+		// Enter is ignored while no item matches
+		{
+			userInput: "xyz\r\x15\x1b[B\r",
+			expect:    1,
+		},
+	}
+
+	list := []string{"dev", "staging", "production", "jenkins", "jk-prod"}
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+		}
+
+		m := newMenu("", newItems(list), -1, true)
+		n, err := ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString(c.userInput))}, m, &Options{})
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if n != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, list[n], list[c.expect])
+		}
+	}
+}
+
+func TestRunMenu_page(t *testing.T) {
+	var list []string
+	for i := 1; i <= 10; i++ {
+		list = append(list, fmt.Sprintf("item%d", i))
+	}
+
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+	}
+
+	m := newMenu("Which?", newItems(list), -1, false)
+	m.setPageSize(3)
+
+	n, err := ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString("jjj\x1b[6~\r"))}, m, &Options{})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if n != 6 {
+		t.Fatalf("expect %q to be eq %q", list[n], list[6])
+	}
+
+	// The cursor moved to item4, so the viewport scrolled by an item
+	expect := "  item2\r\n  item3\r\n> " + escReverse + "item4" + escReset + "\r\n  (2-4 of 10)\r\n"
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+
+	if strings.Contains(out.String(), "item8") {
+		t.Fatalf("expect item8 not to be displayed: %q", out.String())
+	}
+}
+
+func TestRunMenu_items(t *testing.T) {
+	items := []Item{
+		{Label: "Staging", Heading: true},
+		{Label: "stg"},
+		{Label: "Production", Heading: true},
+		{Label: "prod", Disabled: true, Reason: "deploy freeze"},
+		{Label: "prod-canary"},
+	}
+
+	cases := []struct {
+		cursor    int
+		filter    bool
+		userInput string
+		expect    int
+	}{
+		// The cursor starts on the first item, not the heading
+		{
+			cursor:    -1,
+			userInput: "\r",
+			expect:    1,
+		},
+
+		// Headings are skipped
+		{
+			cursor:    -1,
+			userInput: "jj\r",
+			expect:    4,
+		},
+
+		{
+			cursor:    4,
+			userInput: "kkk\r",
+			expect:    1,
+		},
+
+		// Disabled item can not be selected
+		{
+			cursor:    -1,
+			userInput: "j\rj\r",
+			expect:    4,
+		},
+
+		// Headings are not matched by the filter
+		{
+			cursor:    -1,
+			filter:    true,
+			userInput: "Prod\x1b[B\r",
+			expect:    4,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+		}
+
+		m := newMenu("", items, c.cursor, c.filter)
+		n, err := ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString(c.userInput))}, m, &Options{Loop: true})
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if n != c.expect {
+			t.Fatalf("#%d expect %d to be eq %d", i, n, c.expect)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	got := highlight("prod", []int{0, 2})
+	expect := escUnderline + "p" + escNoUnderline + "r" + escUnderline + "o" + escNoUnderline + "d"
+	if got != expect {
+		t.Fatalf("expect %q to be eq %q", got, expect)
+	}
+}
+
+func TestKeyReader(t *testing.T) {
+	kr := &keyReader{
+		in: newPump(bytes.NewBufferString("a\x1b[A\x1b[B\x1b[3~\x1bOHü\r")),
+	}
+
+	expect := []key{'a', keyUp, keyDown, keyDelete, keyHome, 'ü', keyCR}
+	for i, e := range expect {
+		k, err := kr.readKey(context.Background())
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if k != e {
+			t.Fatalf("#%d expect %d to be eq %d", i, k, e)
+		}
+	}
+}
+
+func TestRunMenu_description(t *testing.T) {
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+	}
+
+	items := []Item{
+		{Label: "go", Description: "lower"},
+		{Label: "golang", Description: "long"},
+	}
+
+	m := newMenu("", items, -1, true)
+	if _, err := ui.runMenu(context.Background(), &keyReader{in: newPump(bytes.NewBufferString("gol\r"))}, m, &Options{}); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	// The matched runes are highlighted and the description is aligned
+	// by the width of the label without escape sequences.
+	expect := highlight("golang", []int{0, 1, 2}) + "  " + escDim + "long" + escNormal
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+}
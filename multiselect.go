@@ -0,0 +1,319 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MultiSelectOptions is structure contains option for MultiSelect.
+type MultiSelectOptions struct {
+	// Options is the common options. Options.Default is not used,
+	// use Defaults instead.
+	Options
+
+	// Defaults are the items which are selected when nothing
+	// is input. Each of them must exist in the list.
+	Defaults []string
+
+	// Min and Max are the minimum and maximum number of items
+	// to be selected. Max is checked only when it's more than 0.
+	Min int
+	Max int
+}
+
+// MultiSelect asks the user to select items from the given list by
+// the numbers. The numbers are separated by comma and a range of numbers
+// can be specified like "5-7". "all" selects all items. The selected
+// items are returned in the order of the list. It checks the input
+// and returns ErrNotNumber, ErrOutOfRange or ErrSelectionCount. If Loop
+// is true, it continue to ask until it receives valid input.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func (i *UI) MultiSelect(query string, list []string, opts *MultiSelectOptions) (selected []string, err error) {
+	// Set default val
+	if err := i.setup(); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err == nil {
+			i.recordAnswer(&opts.Options, selected)
+		}
+	}()
+
+	// Find default indexes which opts.Defaults indicates
+	var defaultNums []string
+	for _, defaultVal := range opts.Defaults {
+		defaultIndex := -1
+		for i, item := range list {
+			if item == defaultVal {
+				defaultIndex = i
+			}
+		}
+
+		// DefaultVal is set but doesn't exist in list
+		if defaultIndex == -1 {
+			// This error message is not for user
+			// Should be found while development
+			return nil, fmt.Errorf("opt.Defaults is specified but item does not exist in list")
+		}
+
+		defaultNums = append(defaultNums, strconv.Itoa(defaultIndex+1))
+	}
+
+	// Use the resolved items without asking if they are valid
+	if resolved, ok := i.resolveList(opts.Key); ok {
+		result, err := resolveSelection(list, resolved, opts)
+		if err == nil {
+			return result, nil
+		}
+
+		if err := i.rejectResolved(&opts.Options, err); err != nil {
+			return nil, err
+		}
+	}
+
+	// Use the default items without asking in non-interactive mode
+	if i.nonInteractive() {
+		if len(defaultNums) == 0 {
+			return nil, &NonInteractiveError{Query: query}
+		}
+
+		indexes, _ := parseSelection(strings.Join(defaultNums, ","), len(list))
+		result := make([]string, len(indexes))
+		for n, index := range indexes {
+			result[n] = list[index]
+		}
+
+		return result, nil
+	}
+
+	// Construct the query & display it to user
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s\n\n", query))
+	for i, item := range list {
+		buf.WriteString(fmt.Sprintf("%d. %s\n", i+1, item))
+	}
+
+	buf.WriteString("\n")
+	fmt.Fprint(i.Writer, buf.String())
+
+	// result and resultErr are return val of this function
+	var result []string
+	var resultErr error
+
+	// attempt is incremented when the input is rejected
+	attempt := 1
+	for {
+
+		// Construct the asking line to input
+		var buf bytes.Buffer
+		buf.WriteString("Enter numbers separated by comma (e.g., 1,3,5-7 or all)")
+
+		// Add default val if provided
+		if len(defaultNums) > 0 && !opts.HideDefault {
+			buf.WriteString(fmt.Sprintf(" (Default is %s)", strings.Join(defaultNums, ",")))
+		}
+
+		buf.WriteString(": ")
+		fmt.Fprint(i.Writer, buf.String())
+
+		// Read user input from reader.
+		line, err := i.read(opts.readOpts())
+		if err != nil {
+			resultErr = err
+			break
+		}
+
+		// Go back to the previous question
+		if opts.BackCommand != "" && line == opts.BackCommand {
+			resultErr = ErrBack
+			break
+		}
+
+		// line is empty but default is provided uses it
+		if line == "" && len(defaultNums) > 0 {
+			line = strings.Join(defaultNums, ",")
+		}
+
+		if line == "" && opts.Min > 0 {
+			if err := opts.giveUp(attempt, ErrEmpty); err != nil {
+				resultErr = err
+				break
+			}
+
+			attempt++
+			fmt.Fprintf(i.Writer, "Input must not be empty. Answer by numbers.\n\n")
+			continue
+		}
+
+		// Convert user input string to the indexes of list
+		indexes, err := parseSelection(line, len(list))
+		if err != nil {
+			if err := opts.giveUp(attempt, err); err != nil {
+				resultErr = err
+				break
+			}
+
+			attempt++
+
+			if err == ErrOutOfRange {
+				fmt.Fprintf(i.Writer,
+					"%q is not a valid choice. Choose numbers from 1 to %d.\n\n",
+					line, len(list))
+			} else {
+				fmt.Fprintf(i.Writer,
+					"%q is not a valid input. Answer by numbers.\n\n", line)
+			}
+			continue
+		}
+
+		// Check the number of selected items
+		if len(indexes) < opts.Min || (opts.Max > 0 && opts.Max < len(indexes)) {
+			if err := opts.giveUp(attempt, ErrSelectionCount); err != nil {
+				resultErr = err
+				break
+			}
+
+			attempt++
+			fmt.Fprintf(i.Writer, "%s.\n\n", selectionCountMessage(opts.Min, opts.Max))
+			continue
+		}
+
+		// validate input by custom function
+		if err := opts.validate(line, attempt); err != nil {
+			if err := opts.giveUp(attempt, err); err != nil {
+				resultErr = err
+				break
+			}
+
+			attempt++
+			fmt.Fprintf(i.Writer, "Failed to validate input string: %s\n\n", err)
+			continue
+		}
+
+		// Reach here means it gets ideal input.
+		result = make([]string, 0, len(indexes))
+		for _, index := range indexes {
+			result = append(result, list[index])
+		}
+		break
+	}
+
+	// Insert the new line for next output
+	fmt.Fprintf(i.Writer, "\n")
+
+	return result, resultErr
+}
+
+// resolveSelection returns the items whose labels are in the resolved
+// answer. It returns the error if the items are not in the list or
+// can not be selected.
+func resolveSelection(list []string, resolved []string, opts *MultiSelectOptions) ([]string, error) {
+	var nums []string
+	for _, label := range resolved {
+		index := -1
+		for n, item := range list {
+			if item == label {
+				index = n
+				break
+			}
+		}
+
+		if index == -1 {
+			return nil, ErrOutOfRange
+		}
+
+		nums = append(nums, strconv.Itoa(index+1))
+	}
+
+	line := strings.Join(nums, ",")
+	indexes, err := parseSelection(line, len(list))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(indexes) < opts.Min || (opts.Max > 0 && opts.Max < len(indexes)) {
+		return nil, ErrSelectionCount
+	}
+
+	if err := opts.validate(line, 1); err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		result = append(result, list[index])
+	}
+
+	return result, nil
+}
+
+// parseSelection parses the selection like "1,3,5-7" or "all" and
+// returns the sorted and deduplicated indexes (0-origin) of the list
+// which has n items.
+func parseSelection(s string, n int) ([]int, error) {
+	selected := make([]bool, n)
+
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "all") {
+		for i := range selected {
+			selected[i] = true
+		}
+	} else if s != "" {
+		for _, field := range strings.Split(s, ",") {
+			field = strings.TrimSpace(field)
+
+			// A single number or a range of numbers like "5-7"
+			from, to := field, field
+			if i := strings.Index(field, "-"); i > 0 {
+				from, to = field[:i], field[i+1:]
+			}
+
+			start, err := strconv.Atoi(strings.TrimSpace(from))
+			if err != nil {
+				return nil, ErrNotNumber
+			}
+
+			end, err := strconv.Atoi(strings.TrimSpace(to))
+			if err != nil {
+				return nil, ErrNotNumber
+			}
+
+			if start < 1 || n < end || end < start {
+				return nil, ErrOutOfRange
+			}
+
+			for i := start; i <= end; i++ {
+				selected[i-1] = true
+			}
+		}
+	}
+
+	var indexes []int
+	for i, ok := range selected {
+		if ok {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes, nil
+}
+
+// selectionCountMessage returns the message which explains how many
+// items must be selected.
+func selectionCountMessage(min, max int) string {
+	switch {
+	case max > 0 && min == max:
+		return fmt.Sprintf("Select %d items", min)
+	case max > 0 && min > 0:
+		return fmt.Sprintf("Select from %d to %d items", min, max)
+	case max > 0:
+		return fmt.Sprintf("Select at most %d items", max)
+	default:
+		return fmt.Sprintf("Select at least %d items", min)
+	}
+}
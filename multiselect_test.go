@@ -0,0 +1,169 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestMultiSelect(t *testing.T) {
+	cases := []struct {
+		list      []string
+		opts      *MultiSelectOptions
+		userInput io.Reader
+		expect    []string
+	}{
+		{
+			list:      []string{"A", "B", "C"},
+			opts:      &MultiSelectOptions{},
+			userInput: bytes.NewBufferString("1,3\n"),
+			expect:    []string{"A", "C"},
+		},
+
+		{
+			list:      []string{"A", "B", "C", "D", "E"},
+			opts:      &MultiSelectOptions{},
+			userInput: bytes.NewBufferString("5, 2-3, 2\n"),
+			expect:    []string{"B", "C", "E"},
+		},
+
+		{
+			list:      []string{"A", "B", "C"},
+			opts:      &MultiSelectOptions{},
+			userInput: bytes.NewBufferString("ALL\n"),
+			expect:    []string{"A", "B", "C"},
+		},
+
+		// Defaults
+		{
+			list: []string{"A", "B", "C"},
+			opts: &MultiSelectOptions{
+				Defaults: []string{"C", "A"},
+			},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    []string{"A", "C"},
+		},
+
+		// Nothing is selected
+		{
+			list:      []string{"A", "B", "C"},
+			opts:      &MultiSelectOptions{},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    []string{},
+		},
+
+		// Loop
+		{
+			list: []string{"A", "B", "C"},
+			opts: &MultiSelectOptions{
+				Options: Options{
+					Loop: true,
+				},
+				Min: 1,
+				Max: 2,
+			},
+			userInput: bytes.NewBufferString("\nA\n4\n1-3\n2\n"),
+			expect:    []string{"B"},
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: c.userInput,
+		}
+
+		ans, err := ui.MultiSelect("", c.list, c.opts)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if !reflect.DeepEqual(ans, c.expect) {
+			t.Fatalf("#%d expect %q to be eq %q", i, ans, c.expect)
+		}
+	}
+}
+
+func TestMultiSelect_error(t *testing.T) {
+	cases := []struct {
+		opts      *MultiSelectOptions
+		userInput io.Reader
+		expect    error
+	}{
+		{
+			opts:      &MultiSelectOptions{},
+			userInput: bytes.NewBufferString("1,B\n"),
+			expect:    ErrNotNumber,
+		},
+
+		{
+			opts:      &MultiSelectOptions{},
+			userInput: bytes.NewBufferString("2-4\n"),
+			expect:    ErrOutOfRange,
+		},
+
+		{
+			opts:      &MultiSelectOptions{},
+			userInput: bytes.NewBufferString("3-1\n"),
+			expect:    ErrOutOfRange,
+		},
+
+		{
+			opts:      &MultiSelectOptions{Max: 1},
+			userInput: bytes.NewBufferString("1,2\n"),
+			expect:    ErrSelectionCount,
+		},
+
+		{
+			opts:      &MultiSelectOptions{Min: 1},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    ErrEmpty,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: c.userInput,
+		}
+
+		_, err := ui.MultiSelect("", []string{"A", "B", "C"}, c.opts)
+		if err != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, err, c.expect)
+		}
+	}
+}
+
+func TestMultiSelect_invalidDefaults(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+	}
+	_, err := ui.MultiSelect("Which?", []string{"A", "B", "C"}, &MultiSelectOptions{
+		// "D" is not in select target list
+		Defaults: []string{"A", "D"},
+	})
+
+	if err == nil {
+		t.Fatal("expect err to be occurr")
+	}
+}
+
+func ExampleUI_MultiSelect() {
+	ui := &UI{
+		// In real world, Reader is os.Stdin and input comes
+		// from user actual input.
+		Reader: bytes.NewBufferString("1,3\n"),
+		Writer: ioutil.Discard,
+	}
+
+	query := "Which regions do you deploy to?"
+	regions, _ := ui.MultiSelect(query, []string{"us-east-1", "eu-west-1", "ap-northeast-1"}, &MultiSelectOptions{
+		Min: 1,
+	})
+
+	fmt.Println(regions)
+	// Output: [us-east-1 ap-northeast-1]
+}
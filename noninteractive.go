@@ -0,0 +1,45 @@
+package input
+
+import (
+	"fmt"
+	"os"
+)
+
+// NonInteractiveError is returned when the prompt can not be answered
+// because UI is non-interactive (see UI.NonInteractive) and the prompt
+// has no default value. It matches ErrNonInteractive with errors.Is.
+type NonInteractiveError struct {
+	// Query is the query of the prompt which could not be answered.
+	Query string
+}
+
+// Error implements error.
+func (e *NonInteractiveError) Error() string {
+	return fmt.Sprintf("%s: %q can not be answered", ErrNonInteractive, e.Query)
+}
+
+// Is reports whether target is ErrNonInteractive.
+func (e *NonInteractiveError) Is(target error) bool {
+	return target == ErrNonInteractive
+}
+
+// nonInteractive returns true if UI must not read the input. UI must
+// be set up before it's called.
+func (i *UI) nonInteractive() bool {
+	if i.NonInteractive {
+		return true
+	}
+
+	if !i.DetectNonInteractive {
+		return false
+	}
+
+	return isCI() || i.terminal() == nil
+}
+
+// isCI returns true if it's running in CI. Most CI services set
+// the CI environment variable.
+func isCI() bool {
+	ci := os.Getenv("CI")
+	return ci != "" && ci != "false" && ci != "0"
+}
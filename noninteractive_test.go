@@ -0,0 +1,125 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestNonInteractive(t *testing.T) {
+	// The reader which never returns the input
+	r, w := io.Pipe()
+	defer w.Close()
+
+	ui := &UI{
+		Writer:         ioutil.Discard,
+		Reader:         r,
+		NonInteractive: true,
+	}
+
+	ans, err := ui.Ask("What is your name?", &Options{Default: "tcnksm"})
+	if err != nil || ans != "tcnksm" {
+		t.Fatalf("expect %q to be eq %q: %v", ans, "tcnksm", err)
+	}
+
+	n, err := ui.AskInt("How many?", &IntRange{Min: intp(1), Max: intp(10)}, &Options{Default: "3"})
+	if err != nil || n != 3 {
+		t.Fatalf("expect %d to be eq %d: %v", n, 3, err)
+	}
+
+	yes, err := ui.Confirm("Apply?", &ConfirmOptions{Default: true})
+	if err != nil || !yes {
+		t.Fatalf("expect %v to be eq %v: %v", yes, true, err)
+	}
+
+	item, err := ui.Select("Which?", []string{"A", "B", "C"}, &Options{Default: "B"})
+	if err != nil || item != "B" {
+		t.Fatalf("expect %q to be eq %q: %v", item, "B", err)
+	}
+
+	items, err := ui.MultiSelect("Which?", []string{"A", "B", "C"}, &MultiSelectOptions{Defaults: []string{"C", "A"}})
+	if err != nil || !reflect.DeepEqual(items, []string{"A", "C"}) {
+		t.Fatalf("expect %q to be eq %q: %v", items, []string{"A", "C"}, err)
+	}
+}
+
+func TestNonInteractive_noDefault(t *testing.T) {
+	ui := &UI{
+		Writer:         ioutil.Discard,
+		Reader:         bytes.NewBufferString("tcnksm\n"),
+		NonInteractive: true,
+	}
+
+	cases := []struct {
+		query string
+		f     func(query string) error
+	}{
+		{
+			query: "What is your name?",
+			f: func(query string) error {
+				_, err := ui.Ask(query, &Options{})
+				return err
+			},
+		},
+
+		{
+			query: "Which?",
+			f: func(query string) error {
+				_, err := ui.Select(query, []string{"A", "B"}, &Options{})
+				return err
+			},
+		},
+
+		{
+			query: "Which ones?",
+			f: func(query string) error {
+				_, err := ui.MultiSelect(query, []string{"A", "B"}, &MultiSelectOptions{})
+				return err
+			},
+		},
+	}
+
+	for i, c := range cases {
+		err := c.f(c.query)
+		if !errors.Is(err, ErrNonInteractive) {
+			t.Fatalf("#%d expect %v to be ErrNonInteractive", i, err)
+		}
+
+		var nerr *NonInteractiveError
+		if !errors.As(err, &nerr) || nerr.Query != c.query {
+			t.Fatalf("#%d expect the error to name the query %q: %v", i, c.query, err)
+		}
+	}
+}
+
+func TestDetectNonInteractive(t *testing.T) {
+	cases := []struct {
+		ci        string
+		terminal  bool
+		expectErr bool
+	}{
+		{ci: "", terminal: true, expectErr: false},
+		{ci: "false", terminal: true, expectErr: false},
+		{ci: "true", terminal: true, expectErr: true},
+		{ci: "", terminal: false, expectErr: true},
+	}
+
+	for i, c := range cases {
+		t.Setenv("CI", c.ci)
+
+		ui := &UI{
+			Writer:               ioutil.Discard,
+			Reader:               bytes.NewBufferString("tcnksm\n"),
+			Terminal:             &fakeTerminal{terminal: c.terminal},
+			DetectNonInteractive: true,
+		}
+
+		_, err := ui.Ask("What is your name?", &Options{})
+		if (err != nil) != c.expectErr {
+			t.Fatalf("#%d expect error to be %v: %v", i, c.expectErr, err)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package input
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// IntRange is the range of values accepted by AskInt. Min and Max
+// are inclusive and nil means no bound, e.g., only Min is set for
+// "at least Min". If Step is more than 1, the value must be Min (or
+// 0 if Min is nil) plus a multiple of Step.
+type IntRange struct {
+	Min  *int
+	Max  *int
+	Step int
+}
+
+// FloatRange is the range of values accepted by AskFloat. Min and
+// Max are inclusive and nil means no bound. If Step is more than 0,
+// the value must be Min (or 0 if Min is nil) plus a multiple of Step.
+type FloatRange struct {
+	Min  *float64
+	Max  *float64
+	Step float64
+}
+
+// AskInt asks the user for an integer using the given query. The input
+// must be a number in the given range (nil means any number). If not,
+// it returns ErrNotNumber or ErrOutOfRange, or continues to ask when
+// Loop is true. opts.Default must also be a number.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func (i *UI) AskInt(query string, r *IntRange, opts *Options) (int, error) {
+	if r == nil {
+		r = &IntRange{}
+	}
+
+	return askValue(i, query, r.hint(), Parser[int]{
+		Parse: func(s string) (int, error) {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				return 0, ErrNotNumber
+			}
+
+			return n, r.check(n)
+		},
+		Format: strconv.Itoa,
+	}, nil, opts)
+}
+
+// AskFloat asks the user for a floating-point number using the given
+// query. It behaves same as AskInt except the value type.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func (i *UI) AskFloat(query string, r *FloatRange, opts *Options) (float64, error) {
+	if r == nil {
+		r = &FloatRange{}
+	}
+
+	return askValue(i, query, r.hint(), Parser[float64]{
+		Parse: func(s string) (float64, error) {
+			// NaN and infinity are not in any range
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				return 0, ErrNotNumber
+			}
+
+			return f, r.check(f)
+		},
+		Format: formatFloat,
+	}, nil, opts)
+}
+
+// check returns ErrOutOfRange if n is not in the range.
+func (r *IntRange) check(n int) error {
+	if (r.Min != nil && n < *r.Min) || (r.Max != nil && *r.Max < n) {
+		return ErrOutOfRange
+	}
+
+	var min int
+	if r.Min != nil {
+		min = *r.Min
+	}
+
+	if r.Step > 1 && (n-min)%r.Step != 0 {
+		return ErrOutOfRange
+	}
+
+	return nil
+}
+
+// hint returns the description of the range which is shown in
+// the instruction to user.
+func (r *IntRange) hint() string {
+	var parts []string
+	switch {
+	case r.Min != nil && r.Max != nil:
+		parts = append(parts, fmt.Sprintf("from %d to %d", *r.Min, *r.Max))
+	case r.Min != nil:
+		parts = append(parts, fmt.Sprintf("at least %d", *r.Min))
+	case r.Max != nil:
+		parts = append(parts, fmt.Sprintf("at most %d", *r.Max))
+	}
+
+	if r.Step > 1 {
+		parts = append(parts, fmt.Sprintf("in steps of %d", r.Step))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(parts, " ")
+}
+
+// check returns ErrOutOfRange if f is not in the range.
+func (r *FloatRange) check(f float64) error {
+	if (r.Min != nil && f < *r.Min) || (r.Max != nil && *r.Max < f) {
+		return ErrOutOfRange
+	}
+
+	var min float64
+	if r.Min != nil {
+		min = *r.Min
+	}
+
+	if r.Step > 0 {
+		// Allow the error which comes from floating-point arithmetic.
+		steps := (f - min) / r.Step
+		if math.Abs(steps-math.Floor(steps+0.5)) > 1e-9 {
+			return ErrOutOfRange
+		}
+	}
+
+	return nil
+}
+
+// hint returns the description of the range which is shown in
+// the instruction to user.
+func (r *FloatRange) hint() string {
+	var parts []string
+	switch {
+	case r.Min != nil && r.Max != nil:
+		parts = append(parts, fmt.Sprintf("from %s to %s", formatFloat(*r.Min), formatFloat(*r.Max)))
+	case r.Min != nil:
+		parts = append(parts, fmt.Sprintf("at least %s", formatFloat(*r.Min)))
+	case r.Max != nil:
+		parts = append(parts, fmt.Sprintf("at most %s", formatFloat(*r.Max)))
+	}
+
+	if r.Step > 0 {
+		parts = append(parts, fmt.Sprintf("in steps of %s", formatFloat(r.Step)))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(parts, " ")
+}
+
+// formatFloat formats f with the minimum number of digits.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
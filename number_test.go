@@ -0,0 +1,340 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestAskInt(t *testing.T) {
+	cases := []struct {
+		r         *IntRange
+		opts      *Options
+		userInput io.Reader
+		expect    int
+	}{
+		{
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("42\n"),
+			expect:    42,
+		},
+
+		{
+			opts: &Options{
+				Default: "8080",
+			},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    8080,
+		},
+
+		{
+			r:         &IntRange{Min: intp(1), Max: intp(10)},
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("10\n"),
+			expect:    10,
+		},
+
+		// Loop
+		{
+			r: &IntRange{Min: intp(1), Max: intp(10)},
+			opts: &Options{
+				Loop: true,
+			},
+			userInput: bytes.NewBufferString("a\n11\n0\n5\n"),
+			expect:    5,
+		},
+
+		// Only the lower bound
+		{
+			r: &IntRange{Min: intp(1)},
+			opts: &Options{
+				Loop: true,
+			},
+			userInput: bytes.NewBufferString("-5\n0\n1000\n"),
+			expect:    1000,
+		},
+
+		// The range of a single value
+		{
+			r: &IntRange{Min: intp(3), Max: intp(3)},
+			opts: &Options{
+				Loop: true,
+			},
+			userInput: bytes.NewBufferString("2\n4\n3\n"),
+			expect:    3,
+		},
+
+		// Loop with step
+		{
+			r: &IntRange{Min: intp(0), Max: intp(100), Step: 25},
+			opts: &Options{
+				Loop: true,
+			},
+			userInput: bytes.NewBufferString("30\n75\n"),
+			expect:    75,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: c.userInput,
+		}
+
+		ans, err := ui.AskInt("", c.r, c.opts)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if ans != c.expect {
+			t.Fatalf("#%d expect %d to be eq %d", i, ans, c.expect)
+		}
+	}
+}
+
+func TestAskInt_error(t *testing.T) {
+	cases := []struct {
+		r         *IntRange
+		opts      *Options
+		userInput io.Reader
+		expect    error
+	}{
+		{
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("ten\n"),
+			expect:    ErrNotNumber,
+		},
+
+		{
+			r:         &IntRange{Min: intp(1), Max: intp(10)},
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("11\n"),
+			expect:    ErrOutOfRange,
+		},
+
+		{
+			r:         &IntRange{Min: intp(1), Max: intp(10), Step: 3},
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("5\n"),
+			expect:    ErrOutOfRange,
+		},
+
+		{
+			r:         &IntRange{Min: intp(1)},
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("-5\n"),
+			expect:    ErrOutOfRange,
+		},
+
+		{
+			r:         &IntRange{Max: intp(-1)},
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("0\n"),
+			expect:    ErrOutOfRange,
+		},
+
+		{
+			opts: &Options{
+				Required: true,
+			},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    ErrEmpty,
+		},
+
+		// The empty input is not parsed
+		{
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    ErrEmpty,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: c.userInput,
+		}
+
+		_, err := ui.AskInt("", c.r, c.opts)
+		if err != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, err, c.expect)
+		}
+	}
+}
+
+func TestAskInt_hint(t *testing.T) {
+	cases := []struct {
+		r      *IntRange
+		expect string
+	}{
+		{
+			r:      &IntRange{Min: intp(0), Max: intp(10), Step: 5},
+			expect: "Enter a value from 0 to 10 in steps of 5: ",
+		},
+
+		{
+			r:      &IntRange{Min: intp(1)},
+			expect: "Enter a value at least 1: ",
+		},
+
+		{
+			r:      &IntRange{Max: intp(10)},
+			expect: "Enter a value at most 10: ",
+		},
+	}
+
+	for i, c := range cases {
+		var out bytes.Buffer
+		ui := &UI{
+			Writer: &out,
+			Reader: bytes.NewBufferString("5\n"),
+		}
+
+		if _, err := ui.AskInt("How many?", c.r, &Options{}); err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if !strings.Contains(out.String(), c.expect) {
+			t.Fatalf("#%d expect %q to contain %q", i, out.String(), c.expect)
+		}
+	}
+}
+
+func TestAskFloat(t *testing.T) {
+	cases := []struct {
+		r         *FloatRange
+		opts      *Options
+		userInput io.Reader
+		expect    float64
+	}{
+		{
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("3.14\n"),
+			expect:    3.14,
+		},
+
+		{
+			opts: &Options{
+				Default: "0.5",
+			},
+			userInput: bytes.NewBufferString("\n"),
+			expect:    0.5,
+		},
+
+		// Only the upper bound
+		{
+			r: &FloatRange{Max: floatp(1)},
+			opts: &Options{
+				Loop: true,
+			},
+			userInput: bytes.NewBufferString("1.5\n-2.5\n"),
+			expect:    -2.5,
+		},
+
+		// Loop with step
+		{
+			r: &FloatRange{Min: floatp(0), Max: floatp(1), Step: 0.1},
+			opts: &Options{
+				Loop: true,
+			},
+			userInput: bytes.NewBufferString("1.5\n0.25\n0.3\n"),
+			expect:    0.3,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: c.userInput,
+		}
+
+		ans, err := ui.AskFloat("", c.r, c.opts)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if ans != c.expect {
+			t.Fatalf("#%d expect %v to be eq %v", i, ans, c.expect)
+		}
+	}
+}
+
+func TestAskFloat_error(t *testing.T) {
+	cases := []struct {
+		r         *FloatRange
+		opts      *Options
+		userInput io.Reader
+		expect    error
+	}{
+		{
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("pi\n"),
+			expect:    ErrNotNumber,
+		},
+
+		{
+			r:         &FloatRange{Min: floatp(0), Max: floatp(1)},
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("1.5\n"),
+			expect:    ErrOutOfRange,
+		},
+
+		{
+			r:         &FloatRange{Min: floatp(0), Max: floatp(10)},
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("NaN\n"),
+			expect:    ErrNotNumber,
+		},
+
+		{
+			r:         &FloatRange{Step: 0.5},
+			opts:      &Options{},
+			userInput: bytes.NewBufferString("Inf\n"),
+			expect:    ErrNotNumber,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: c.userInput,
+		}
+
+		_, err := ui.AskFloat("", c.r, c.opts)
+		if err != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, err, c.expect)
+		}
+	}
+}
+
+func ExampleUI_AskInt() {
+	ui := &UI{
+		// In real world, Reader is os.Stdin and input comes
+		// from user actual input.
+		Reader: bytes.NewBufferString("3\n"),
+		Writer: ioutil.Discard,
+	}
+
+	query := "How many replicas?"
+	min, max := 1, 5
+	n, _ := ui.AskInt(query, &IntRange{Min: &min, Max: &max}, &Options{
+		Default: "1",
+		Loop:    true,
+	})
+
+	fmt.Println(n)
+	// Output: 3
+}
+
+// intp returns the pointer to n.
+func intp(n int) *int {
+	return &n
+}
+
+// floatp returns the pointer to f.
+func floatp(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,294 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Built-in parsers for AskValue.
+var (
+	// DurationParser parses duration like "1h30m" by time.ParseDuration.
+	DurationParser = Parser[time.Duration]{
+		Parse:  time.ParseDuration,
+		Format: time.Duration.String,
+	}
+
+	// URLParser parses absolute URL which has both scheme and host.
+	URLParser = Parser[*url.URL]{
+		Parse:  parseURL,
+		Format: (*url.URL).String,
+	}
+
+	// IPParser parses IPv4 or IPv6 address.
+	IPParser = Parser[net.IP]{
+		Parse:  parseIP,
+		Format: net.IP.String,
+	}
+
+	// CIDRParser parses IP network in CIDR notation like "10.0.0.0/8".
+	CIDRParser = Parser[*net.IPNet]{
+		Parse:  parseCIDR,
+		Format: (*net.IPNet).String,
+	}
+
+	// EmailParser parses email address like "gopher@example.com".
+	// The display name is not allowed.
+	EmailParser = Parser[string]{
+		Parse:  parseEmail,
+		Format: func(s string) string { return s },
+	}
+
+	// DateParser parses date like "2006-01-02".
+	DateParser = TimeParser("2006-01-02")
+
+	// ByteSizeParser parses size of bytes like "512", "10MB" or
+	// "10GiB". Units are case-insensitive. Decimal units (KB, MB, ...)
+	// are powers of 1000 and binary units (KiB, MiB, ...) are powers
+	// of 1024.
+	ByteSizeParser = Parser[uint64]{
+		Parse:  parseByteSize,
+		Format: formatByteSize,
+	}
+
+	// VersionParser parses semantic version like "1.2.3-beta+build".
+	// The leading "v" is allowed.
+	VersionParser = Parser[Version]{
+		Parse:  ParseVersion,
+		Format: Version.String,
+	}
+)
+
+// TimeParser returns the parser which parses time by the given layout.
+// See time.Parse for the format of layout.
+func TimeParser(layout string) Parser[time.Time] {
+	return Parser[time.Time]{
+		Parse: func(s string) (time.Time, error) {
+			return time.Parse(layout, s)
+		},
+		Format: func(t time.Time) string {
+			return t.Format(layout)
+		},
+	}
+}
+
+func parseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL", s)
+	}
+
+	return u, nil
+}
+
+func parseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("%q is not an IP address", s)
+	}
+
+	return ip, nil
+}
+
+func parseCIDR(s string) (*net.IPNet, error) {
+	_, ipNet, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a CIDR notation IP network", s)
+	}
+
+	return ipNet, nil
+}
+
+func parseEmail(s string) (string, error) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil || addr.Name != "" || addr.Address != s {
+		return "", fmt.Errorf("%q is not an email address", s)
+	}
+
+	return addr.Address, nil
+}
+
+// byteUnits is multipliers of the units of byte size. The key
+// is lower-cased.
+var byteUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"k":   1000,
+	"kb":  1000,
+	"m":   1000 * 1000,
+	"mb":  1000 * 1000,
+	"g":   1000 * 1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"t":   1000 * 1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"p":   1000 * 1000 * 1000 * 1000 * 1000,
+	"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+func parseByteSize(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+
+	// Split the number and the unit
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || '9' < r) && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	mul, ok := byteUnits[unit]
+	if num == "" || !ok {
+		return 0, fmt.Errorf("%q is not a size of bytes", s)
+	}
+
+	if n, err := strconv.ParseUint(num, 10, 64); err == nil {
+		if n > math.MaxUint64/mul {
+			return 0, fmt.Errorf("%q is too large", s)
+		}
+		return n * mul, nil
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a size of bytes", s)
+	}
+
+	size := f * float64(mul)
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("%q is too large", s)
+	}
+
+	return uint64(size), nil
+}
+
+func formatByteSize(n uint64) string {
+	units := []string{"PiB", "TiB", "GiB", "MiB", "KiB"}
+	for i, unit := range units {
+		mul := uint64(1) << uint(10*(len(units)-i))
+		if n != 0 && n%mul == 0 {
+			return fmt.Sprintf("%d%s", n/mul, unit)
+		}
+	}
+
+	return fmt.Sprintf("%dB", n)
+}
+
+// Version is a semantic version. See https://semver.org.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// String returns the version like "1.2.3-beta+build".
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// ParseVersion parses the given semantic version. The leading "v"
+// is allowed.
+func ParseVersion(s string) (Version, error) {
+	var v Version
+	errInvalid := fmt.Errorf("%q is not a semantic version", s)
+
+	rest := strings.TrimPrefix(s, "v")
+	if i := strings.Index(rest, "+"); i >= 0 {
+		rest, v.Build = rest[:i], rest[i+1:]
+		if !validIdentifiers(v.Build, false) {
+			return Version{}, errInvalid
+		}
+	}
+
+	if i := strings.Index(rest, "-"); i >= 0 {
+		rest, v.Prerelease = rest[:i], rest[i+1:]
+		if !validIdentifiers(v.Prerelease, true) {
+			return Version{}, errInvalid
+		}
+	}
+
+	nums := strings.Split(rest, ".")
+	if len(nums) != 3 {
+		return Version{}, errInvalid
+	}
+
+	for i, dst := range []*uint64{&v.Major, &v.Minor, &v.Patch} {
+		n, err := parseVersionNumber(nums[i])
+		if err != nil {
+			return Version{}, errInvalid
+		}
+		*dst = n
+	}
+
+	return v, nil
+}
+
+// parseVersionNumber parses the numeric part of the version.
+// Leading zeros are not allowed.
+func parseVersionNumber(s string) (uint64, error) {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return 0, errors.New("invalid number")
+	}
+
+	for _, r := range s {
+		if r < '0' || '9' < r {
+			return 0, errors.New("invalid number")
+		}
+	}
+
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// validIdentifiers reports whether s is dot-separated identifiers
+// of pre-release or build metadata. If numeric is true, numeric
+// identifiers must not have leading zeros.
+func validIdentifiers(s string, numeric bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+
+		allDigits := true
+		for _, r := range id {
+			switch {
+			case '0' <= r && r <= '9':
+			case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', r == '-':
+				allDigits = false
+			default:
+				return false
+			}
+		}
+
+		if numeric && allDigits && len(id) > 1 && id[0] == '0' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,172 @@
+package input
+
+import (
+	"testing"
+)
+
+func TestURLParser(t *testing.T) {
+	cases := []struct {
+		input   string
+		success bool
+	}{
+		{input: "https://example.com/path", success: true},
+		{input: "example.com", success: false},
+		{input: "/path", success: false},
+	}
+
+	for i, c := range cases {
+		_, err := URLParser.Parse(c.input)
+		if (err == nil) != c.success {
+			t.Fatalf("#%d expect success to be %v: %v", i, c.success, err)
+		}
+	}
+}
+
+func TestIPParser(t *testing.T) {
+	cases := []struct {
+		input   string
+		success bool
+	}{
+		{input: "192.168.0.1", success: true},
+		{input: "::1", success: true},
+		{input: "192.168.0.256", success: false},
+	}
+
+	for i, c := range cases {
+		_, err := IPParser.Parse(c.input)
+		if (err == nil) != c.success {
+			t.Fatalf("#%d expect success to be %v: %v", i, c.success, err)
+		}
+	}
+}
+
+func TestCIDRParser(t *testing.T) {
+	ipNet, err := CIDRParser.Parse("10.1.2.3/8")
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if got := CIDRParser.Format(ipNet); got != "10.0.0.0/8" {
+		t.Fatalf("expect %q to be eq %q", got, "10.0.0.0/8")
+	}
+
+	if _, err := CIDRParser.Parse("10.0.0.0"); err == nil {
+		t.Fatal("expect err to be occurr")
+	}
+}
+
+func TestEmailParser(t *testing.T) {
+	cases := []struct {
+		input   string
+		success bool
+	}{
+		{input: "gopher@example.com", success: true},
+		{input: "Gopher <gopher@example.com>", success: false},
+		{input: "gopher", success: false},
+	}
+
+	for i, c := range cases {
+		_, err := EmailParser.Parse(c.input)
+		if (err == nil) != c.success {
+			t.Fatalf("#%d expect success to be %v: %v", i, c.success, err)
+		}
+	}
+}
+
+func TestDateParser(t *testing.T) {
+	d, err := DateParser.Parse("2017-04-01")
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if got := DateParser.Format(d); got != "2017-04-01" {
+		t.Fatalf("expect %q to be eq %q", got, "2017-04-01")
+	}
+}
+
+func TestByteSizeParser(t *testing.T) {
+	cases := []struct {
+		input   string
+		expect  uint64
+		success bool
+	}{
+		{input: "512", expect: 512, success: true},
+		{input: "10GiB", expect: 10 << 30, success: true},
+		{input: "10 gib", expect: 10 << 30, success: true},
+		{input: "1.5KB", expect: 1500, success: true},
+		{input: "2M", expect: 2000000, success: true},
+		{input: "GiB", success: false},
+		{input: "10XB", success: false},
+		{input: "100000PiB", success: false},
+	}
+
+	for i, c := range cases {
+		n, err := ByteSizeParser.Parse(c.input)
+		if (err == nil) != c.success {
+			t.Fatalf("#%d expect success to be %v: %v", i, c.success, err)
+		}
+
+		if n != c.expect {
+			t.Fatalf("#%d expect %d to be eq %d", i, n, c.expect)
+		}
+	}
+}
+
+func TestByteSizeParser_format(t *testing.T) {
+	cases := []struct {
+		input  uint64
+		expect string
+	}{
+		{input: 0, expect: "0B"},
+		{input: 1000, expect: "1000B"},
+		{input: 1 << 20, expect: "1MiB"},
+		{input: 3 << 29, expect: "1536MiB"},
+	}
+
+	for i, c := range cases {
+		if got := ByteSizeParser.Format(c.input); got != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, got, c.expect)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		input   string
+		expect  Version
+		success bool
+	}{
+		{
+			input:   "1.2.3",
+			expect:  Version{Major: 1, Minor: 2, Patch: 3},
+			success: true,
+		},
+		{
+			input:   "v0.10.0-rc.1+build.5",
+			expect:  Version{Minor: 10, Prerelease: "rc.1", Build: "build.5"},
+			success: true,
+		},
+		{input: "1.2", success: false},
+		{input: "01.2.3", success: false},
+		{input: "1.2.3-01", success: false},
+		{input: "1.2.3-beta..1", success: false},
+	}
+
+	for i, c := range cases {
+		v, err := ParseVersion(c.input)
+		if (err == nil) != c.success {
+			t.Fatalf("#%d expect success to be %v: %v", i, c.success, err)
+		}
+
+		if v != c.expect {
+			t.Fatalf("#%d expect %v to be eq %v", i, v, c.expect)
+		}
+	}
+}
+
+func TestVersion_String(t *testing.T) {
+	v := Version{Major: 1, Prerelease: "alpha", Build: "001"}
+	if got := v.String(); got != "1.0.0-alpha+001" {
+		t.Fatalf("expect %q to be eq %q", got, "1.0.0-alpha+001")
+	}
+}
@@ -0,0 +1,303 @@
+package input
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// PasswordPolicy is the rules which the new password must satisfy.
+// The zero value allows any password which is not empty.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of the characters.
+	MinLength int
+
+	// RequireUpper, RequireLower, RequireDigit and RequireSymbol
+	// require at least one character of each class. Symbols are
+	// the characters which are not letters nor digits.
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+
+	// DenyList is the passwords which are not allowed, e.g., the
+	// common passwords. They are compared case-insensitively.
+	DenyList []string
+
+	// DenyListFile is the file which has the passwords which are not
+	// allowed line by line same as DenyList. Empty lines and lines
+	// which start with # are ignored.
+	DenyListFile string
+
+	// MinEntropy is the minimum estimated entropy of the password in
+	// bits. It's estimated by the length and the classes of the
+	// characters, and the repeated characters and the sequences
+	// (e.g., aaa or 123) count little.
+	MinEntropy float64
+}
+
+// PolicyError is returned when the password does not satisfy
+// PasswordPolicy. It describes the rule, not the password. It
+// matches ErrPasswordPolicy with errors.Is.
+type PolicyError struct {
+	// Rule is the name of the field of PasswordPolicy which is not
+	// satisfied, e.g., MinLength.
+	Rule string
+
+	msg string
+}
+
+// Error implements error.
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("password %s", e.msg)
+}
+
+// Is reports whether target is ErrPasswordPolicy.
+func (e *PolicyError) Is(target error) bool {
+	return target == ErrPasswordPolicy
+}
+
+// Check returns PolicyError if the password does not satisfy the
+// policy.
+func (p *PasswordPolicy) Check(password string) error {
+	deny, err := p.denyList()
+	if err != nil {
+		return err
+	}
+
+	return p.check(password, deny)
+}
+
+// check checks the password. deny is the lower-cased passwords which
+// are not allowed.
+func (p *PasswordPolicy) check(password string, deny map[string]bool) error {
+	rs := []rune(password)
+	if len(rs) < p.MinLength {
+		return &PolicyError{Rule: "MinLength", msg: fmt.Sprintf("must be at least %d characters", p.MinLength)}
+	}
+
+	c := classify(rs)
+	switch {
+	case p.RequireUpper && !c.upper:
+		return &PolicyError{Rule: "RequireUpper", msg: "must contain an upper case letter"}
+	case p.RequireLower && !c.lower:
+		return &PolicyError{Rule: "RequireLower", msg: "must contain a lower case letter"}
+	case p.RequireDigit && !c.digit:
+		return &PolicyError{Rule: "RequireDigit", msg: "must contain a digit"}
+	case p.RequireSymbol && !c.symbol:
+		return &PolicyError{Rule: "RequireSymbol", msg: "must contain a symbol"}
+	}
+
+	if deny[strings.ToLower(password)] {
+		return &PolicyError{Rule: "DenyList", msg: "is too common"}
+	}
+
+	if e := entropy(rs); e < p.MinEntropy {
+		return &PolicyError{
+			Rule: "MinEntropy",
+			msg:  fmt.Sprintf("is too easy to guess (%.0f bits of entropy, %.0f bits are required)", e, p.MinEntropy),
+		}
+	}
+
+	return nil
+}
+
+// denyList returns the lower-cased passwords of DenyList and
+// DenyListFile.
+func (p *PasswordPolicy) denyList() (map[string]bool, error) {
+	deny := make(map[string]bool, len(p.DenyList))
+	for _, s := range p.DenyList {
+		deny[strings.ToLower(s)] = true
+	}
+
+	if p.DenyListFile == "" {
+		return deny, nil
+	}
+
+	f, err := os.Open(p.DenyListFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deny list: %s", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		deny[strings.ToLower(line)] = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read deny list: %s", err)
+	}
+
+	return deny, nil
+}
+
+// hint returns the hint of the instruction line.
+func (p *PasswordPolicy) hint() string {
+	if p.MinLength <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(" (at least %d characters)", p.MinLength)
+}
+
+// charClasses is the classes of the characters which the password
+// contains.
+type charClasses struct {
+	upper, lower, digit, symbol, other bool
+}
+
+// classify returns the classes of the characters.
+func classify(rs []rune) charClasses {
+	var c charClasses
+	for _, r := range rs {
+		switch {
+		case 'A' <= r && r <= 'Z':
+			c.upper = true
+		case 'a' <= r && r <= 'z':
+			c.lower = true
+		case '0' <= r && r <= '9':
+			c.digit = true
+		case r < 0x80:
+			c.symbol = true
+		default:
+			c.other = true
+		}
+	}
+
+	return c
+}
+
+// poolSize returns the number of the characters in the classes.
+func (c charClasses) poolSize() int {
+	var n int
+	if c.upper {
+		n += 26
+	}
+	if c.lower {
+		n += 26
+	}
+	if c.digit {
+		n += 10
+	}
+	if c.symbol {
+		n += 33
+	}
+	if c.other {
+		n += 100
+	}
+
+	return n
+}
+
+// entropy estimates the entropy of the password in bits. Each
+// character counts log2 of the size of the classes which the password
+// contains, but the character which repeats or follows the previous
+// one (e.g., aaa or abc) counts only 1 bit.
+func entropy(rs []rune) float64 {
+	if len(rs) == 0 {
+		return 0
+	}
+
+	bits := math.Log2(float64(classify(rs).poolSize()))
+
+	var e float64
+	for n, r := range rs {
+		if n > 0 {
+			if d := r - rs[n-1]; -1 <= d && d <= 1 {
+				e++
+				continue
+			}
+		}
+		e += bits
+	}
+
+	return e
+}
+
+// AskNewPassword asks the user for the new password twice with masking
+// and returns it when both inputs are same and it satisfies the policy.
+// If the password does not satisfy the policy, it returns PolicyError.
+// If the inputs are not same, it returns ErrPasswordMismatch. If Loop
+// is true, it continue to ask until it receives valid input or the
+// input is rejected MaxAttempts times. The password is never displayed
+// and Default is not used. Set StrengthMeter to display the strength of
+// the password while the user types it.
+//
+// The resolved password (see UI.Resolvers) is not asked twice.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func (i *UI) AskNewPassword(query string, policy *PasswordPolicy, opts *Options) (string, error) {
+	if err := i.setup(); err != nil {
+		return "", err
+	}
+
+	if policy == nil {
+		policy = &PasswordPolicy{}
+	}
+
+	deny, err := policy.denyList()
+	if err != nil {
+		return "", err
+	}
+
+	// Don't modify the given options
+	o := *opts
+	o.Mask = true
+	o.Required = true
+	o.Default = ""
+
+	validate := func(s string, attempt int) error {
+		if err := policy.check(s, deny); err != nil {
+			return err
+		}
+
+		return o.validate(s, attempt)
+	}
+
+	ctx := context.Background()
+
+	// Nobody can retype the resolved password
+	if _, ok := i.resolve(o.Key); ok || i.nonInteractive() {
+		return i.ask(ctx, query, policy.hint(), &o, validate)
+	}
+
+	retype := &Options{
+		Mask:      true,
+		MaskVal:   o.MaskVal,
+		HideOrder: true,
+	}
+
+	// attempt is incremented when the inputs are not same
+	attempt := 1
+	for {
+		password, err := i.ask(ctx, query, policy.hint(), &o, validate)
+		if err != nil {
+			return "", err
+		}
+
+		retyped, err := i.ask(ctx, "Retype the password", "", retype, retype.validate)
+		if err != nil {
+			return "", err
+		}
+
+		if retyped == password {
+			return password, nil
+		}
+
+		if err := o.giveUp(attempt, ErrPasswordMismatch); err != nil {
+			return "", err
+		}
+
+		attempt++
+		fmt.Fprintf(i.Writer, "Passwords do not match. Enter the new password again.\n\n")
+	}
+}
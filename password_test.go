@@ -0,0 +1,186 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	denyListFile := filepath.Join(dir, "deny.txt")
+	if err := ioutil.WriteFile(denyListFile, []byte("# Common passwords\n\nLetMeIn123!\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &PasswordPolicy{
+		MinLength:     8,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		DenyList:      []string{"Passw0rd!"},
+		DenyListFile:  denyListFile,
+		MinEntropy:    40,
+	}
+
+	cases := []struct {
+		password   string
+		expectRule string
+	}{
+		{password: "Tr0ub4dor&3", expectRule: ""},
+		{password: "Ab1!", expectRule: "MinLength"},
+		{password: "tr0ub4dor&3", expectRule: "RequireUpper"},
+		{password: "TR0UB4DOR&3", expectRule: "RequireLower"},
+		{password: "Troubador&&", expectRule: "RequireDigit"},
+		{password: "Tr0ub4dor33", expectRule: "RequireSymbol"},
+		{password: "pASSw0RD!", expectRule: "DenyList"},
+		{password: "LETMEin123!", expectRule: "DenyList"},
+		{password: "Aa1!aaaaaaaa", expectRule: "MinEntropy"},
+	}
+
+	for i, c := range cases {
+		err := policy.Check(c.password)
+		if c.expectRule == "" {
+			if err != nil {
+				t.Fatalf("#%d expect not to occurr error: %s", i, err)
+			}
+			continue
+		}
+
+		var perr *PolicyError
+		if !errors.As(err, &perr) || !errors.Is(err, ErrPasswordPolicy) {
+			t.Fatalf("#%d expect %v to be PolicyError", i, err)
+		}
+
+		if perr.Rule != c.expectRule {
+			t.Fatalf("#%d expect %q to be eq %q", i, perr.Rule, c.expectRule)
+		}
+
+		// The password is not revealed
+		if strings.Contains(err.Error(), c.password) {
+			t.Fatalf("#%d expect %q not to contain the password", i, err)
+		}
+	}
+
+	policy.DenyListFile = filepath.Join(dir, "not-exist.txt")
+	if err := policy.Check("Tr0ub4dor&3"); err == nil {
+		t.Fatalf("expect error to occurr")
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	cases := []struct {
+		password string
+		min, max float64
+	}{
+		{password: "", min: 0, max: 0},
+		{password: "aaaaaaaa", min: 11, max: 12},
+		{password: "abcdefgh", min: 11, max: 12},
+		{password: "qwhtkzmx", min: 37, max: 38},
+		{password: "Tr0ub4dor&3", min: 70, max: 73},
+	}
+
+	for i, c := range cases {
+		if e := entropy([]rune(c.password)); e < c.min || c.max < e {
+			t.Fatalf("#%d expect %f to be in [%f, %f]", i, e, c.min, c.max)
+		}
+	}
+}
+
+func TestAskNewPassword(t *testing.T) {
+	policy := &PasswordPolicy{MinLength: 8}
+
+	cases := []struct {
+		opts      *Options
+		userInput string
+		expect    string
+		expectErr error
+	}{
+		{
+			opts:      &Options{},
+			userInput: "passw0rd\npassw0rd\n",
+			expect:    "passw0rd",
+		},
+
+		{
+			opts:      &Options{},
+			userInput: "passw0rd\npassword\n",
+			expectErr: ErrPasswordMismatch,
+		},
+
+		{
+			opts:      &Options{},
+			userInput: "short\n",
+			expectErr: ErrPasswordPolicy,
+		},
+
+		{
+			opts:      &Options{Loop: true},
+			userInput: "short\npassw0rd\npassword\npassw0rd\npassw0rd\n",
+			expect:    "passw0rd",
+		},
+
+		{
+			opts:      &Options{Loop: true, MaxAttempts: 2},
+			userInput: "passw0rd\npassword\npassw0rd\n\n",
+			expectErr: ErrTooManyAttempts,
+		},
+
+		// Default is not used
+		{
+			opts:      &Options{Default: "passw0rd"},
+			userInput: "\n",
+			expectErr: ErrEmpty,
+		},
+	}
+
+	for i, c := range cases {
+		var out bytes.Buffer
+		ui := &UI{
+			Writer: &out,
+			Reader: bytes.NewBufferString(c.userInput),
+		}
+
+		ans, err := ui.AskNewPassword("New password?", policy, c.opts)
+		if !errors.Is(err, c.expectErr) || (err == nil) != (c.expectErr == nil) {
+			t.Fatalf("#%d expect %v to be %v", i, err, c.expectErr)
+		}
+
+		if ans != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, ans, c.expect)
+		}
+
+		if strings.Contains(out.String(), "passw0rd") {
+			t.Fatalf("#%d expect the password not to be displayed: %q", i, out.String())
+		}
+	}
+}
+
+func TestAskNewPassword_resolved(t *testing.T) {
+	ui := &UI{
+		Writer:         ioutil.Discard,
+		Reader:         bytes.NewBufferString(""),
+		NonInteractive: true,
+		Resolvers:      []Resolver{MapResolver{"password": "passw0rd"}},
+	}
+
+	ans, err := ui.AskNewPassword("New password?", nil, &Options{Key: "password"})
+	if err != nil || ans != "passw0rd" {
+		t.Fatalf("expect %q to be eq %q: %v", ans, "passw0rd", err)
+	}
+
+	_, err = ui.AskNewPassword("New password?", &PasswordPolicy{MinLength: 10}, &Options{Key: "password"})
+	if !errors.Is(err, ErrPasswordPolicy) {
+		t.Fatalf("expect %v to be %v", err, ErrPasswordPolicy)
+	}
+}
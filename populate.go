@@ -0,0 +1,471 @@
+package input
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Populate asks the user for the values of the fields of the struct
+// which ptr points to and sets them. The prompt of each field is
+// decided by its type and the following tags:
+//
+//	input:"name,required"  the key of the prompt (see Options.Key) and
+//	                       the options: required, loop. "-" skips it.
+//	prompt:"What is ...?"  the query. By default, the path of the field.
+//	default:"tcnksm"       the default value. By default, the current
+//	                       value of the field if it's not zero.
+//	choices:"a,b,c"        selects the value from the choices by Select
+//	                       (or MultiSelect for slices).
+//	mask:"true"            masks the input.
+//
+// Strings, numbers, time.Duration and the types which implement
+// encoding.TextUnmarshaler are asked by Ask and parsed. Bools are asked
+// by Confirm. Slices are asked as the values separated by comma. Nested
+// structs (and pointers to them) are populated recursively, and the keys
+// of their fields are prefixed with the key of the struct, e.g., db.host.
+//
+// It asks all fields even if some of them are invalid and returns
+// PopulateError which describes every invalid field. It stops when
+// the user sends SIGINT (Ctrl+C), the input ends or there is no
+// terminal.
+func (i *UI) Populate(ptr interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		// This error message is not for user
+		// Should be found while development
+		return fmt.Errorf("Populate requires non-nil pointer to struct, but %T is given", ptr)
+	}
+
+	var perr PopulateError
+	if err := i.populateStruct(v.Elem(), "", "", &perr); err != nil {
+		return err
+	}
+
+	if len(perr.Errors) > 0 {
+		return &perr
+	}
+
+	return nil
+}
+
+// PopulateError is returned by Populate when some fields are invalid.
+type PopulateError struct {
+	Errors []*FieldError
+}
+
+// Error implements error.
+func (e *PopulateError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("%d invalid field(s): %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the fields.
+func (e *PopulateError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// FieldError is the error of the field which Populate could not set.
+type FieldError struct {
+	// Field is the path of the field, e.g., DB.Host.
+	Field string
+
+	// Key is the key of the prompt, e.g., db.host.
+	Key string
+
+	// Err is the error returned by the prompt.
+	Err error
+}
+
+// Error implements error.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Err)
+}
+
+// Unwrap returns the error returned by the prompt.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// fieldTag is the parsed tags of the field.
+type fieldTag struct {
+	key      string
+	required bool
+	loop     bool
+	prompt   string
+	def      string
+	choices  []string
+	mask     bool
+}
+
+// parseFieldTag parses the tags of the struct field. It returns false
+// if the field is skipped.
+func parseFieldTag(sf reflect.StructField) (*fieldTag, bool) {
+	tag := &fieldTag{
+		key:    strings.ToLower(sf.Name),
+		prompt: sf.Tag.Get("prompt"),
+		def:    sf.Tag.Get("default"),
+	}
+
+	if s, ok := sf.Tag.Lookup("input"); ok {
+		if s == "-" {
+			return nil, false
+		}
+
+		parts := strings.Split(s, ",")
+		if parts[0] != "" {
+			tag.key = parts[0]
+		}
+
+		for _, opt := range parts[1:] {
+			switch strings.TrimSpace(opt) {
+			case "required":
+				tag.required = true
+			case "loop":
+				tag.loop = true
+			}
+		}
+	}
+
+	if s := sf.Tag.Get("choices"); s != "" {
+		for _, choice := range strings.Split(s, ",") {
+			tag.choices = append(tag.choices, strings.TrimSpace(choice))
+		}
+	}
+
+	tag.mask, _ = strconv.ParseBool(sf.Tag.Get("mask"))
+
+	return tag, true
+}
+
+// populateStruct populates the fields of the struct v. path and key are
+// the prefixes of the fields. The errors of the fields are added to perr
+// and the error which stops populating is returned.
+func (i *UI) populateStruct(v reflect.Value, path, key string, perr *PopulateError) error {
+	t := v.Type()
+	for n := 0; n < t.NumField(); n++ {
+		sf := t.Field(n)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		tag, ok := parseFieldTag(sf)
+		if !ok {
+			continue
+		}
+
+		fieldPath, fieldKey := sf.Name, tag.key
+		if path != "" {
+			fieldPath, fieldKey = path+"."+fieldPath, key+"."+fieldKey
+		}
+		tag.key = fieldKey
+
+		if tag.prompt == "" {
+			tag.prompt = fieldPath
+		}
+
+		f := v.Field(n)
+
+		// Nested structs
+		if isNestedStruct(f.Type()) {
+			if f.Kind() == reflect.Ptr {
+				if f.IsNil() {
+					f.Set(reflect.New(f.Type().Elem()))
+				}
+				f = f.Elem()
+			}
+
+			if err := i.populateStruct(f, fieldPath, fieldKey, perr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := i.populateField(f, tag)
+		switch err {
+		case nil:
+		case ErrInterrupted, ErrEOF, ErrNoTTY:
+			return err
+		default:
+			perr.Errors = append(perr.Errors, &FieldError{Field: fieldPath, Key: fieldKey, Err: err})
+		}
+	}
+
+	return nil
+}
+
+// isNestedStruct returns true if t is the struct (or the pointer to it)
+// which is populated recursively.
+func isNestedStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Struct && !isTextUnmarshaler(t)
+}
+
+// populateField asks the value of the field and sets it.
+func (i *UI) populateField(f reflect.Value, tag *fieldTag) error {
+	opts := &Options{
+		Key:      tag.key,
+		Default:  tag.def,
+		Required: tag.required,
+		Loop:     tag.loop,
+		Mask:     tag.mask,
+	}
+
+	// Use the current value as the default
+	if opts.Default == "" && !f.IsZero() {
+		opts.Default = formatField(f)
+	}
+	hint := opts.hideMaskedDefault()
+
+	if f.Kind() == reflect.Bool {
+		def, err := parseYesNo(opts.Default, false)
+		if err != nil {
+			return fmt.Errorf("default is invalid: %s", err)
+		}
+
+		yes, err := i.Confirm(tag.prompt, &ConfirmOptions{
+			Key:     opts.Key,
+			Default: def,
+			Loop:    opts.Loop,
+		})
+		if err != nil {
+			return err
+		}
+
+		f.SetBool(yes)
+		return nil
+	}
+
+	if f.Kind() == reflect.Slice && !isTextUnmarshaler(f.Type()) {
+		return i.populateSlice(f, tag, opts, hint)
+	}
+
+	parse, ok := fieldParser(f.Type())
+	if !ok {
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+
+	if len(tag.choices) > 0 {
+		s, err := i.Select(tag.prompt, tag.choices, opts)
+		if err != nil {
+			return err
+		}
+
+		v, err := parse(s)
+		if err != nil {
+			return err
+		}
+
+		f.Set(v)
+		return nil
+	}
+
+	v, err := askValue(i, tag.prompt, hint, Parser[reflect.Value]{
+		Parse:  parse,
+		Format: formatField,
+	}, nil, opts)
+
+	// Leave the field as it is if nothing is input
+	if err == ErrEmpty && !opts.Required {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	f.Set(v)
+	return nil
+}
+
+// populateSlice asks the values of the slice field and sets them. hint
+// is added to the instruction when the values are input by Ask.
+func (i *UI) populateSlice(f reflect.Value, tag *fieldTag, opts *Options, hint string) error {
+	parse, ok := fieldParser(f.Type().Elem())
+	if !ok {
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+
+	var values []string
+	if len(tag.choices) > 0 {
+		var defaults []string
+		if opts.Default != "" {
+			defaults = splitValues(opts.Default)
+		}
+
+		min := 0
+		if opts.Required {
+			min = 1
+		}
+
+		selected, err := i.MultiSelect(tag.prompt, tag.choices, &MultiSelectOptions{
+			Options:  *opts,
+			Defaults: defaults,
+			Min:      min,
+		})
+		if err != nil {
+			return err
+		}
+		values = selected
+	} else {
+		s, err := i.ask(context.Background(), tag.prompt, " separated by comma"+hint, opts, func(s string, attempt int) error {
+			for _, value := range splitValues(s) {
+				if _, err := parse(value); err != nil {
+					return err
+				}
+			}
+
+			return opts.validate(s, attempt)
+		})
+		if err != nil {
+			return err
+		}
+
+		// Leave the field as it is if nothing is input
+		if s == "" {
+			return nil
+		}
+		values = splitValues(s)
+	}
+
+	slice := reflect.MakeSlice(f.Type(), 0, len(values))
+	for _, value := range values {
+		v, err := parse(value)
+		if err != nil {
+			return err
+		}
+		slice = reflect.Append(slice, v)
+	}
+
+	f.Set(slice)
+	return nil
+}
+
+// splitValues splits the values separated by comma.
+func splitValues(s string) []string {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// isTextUnmarshaler returns true if the pointer to t implements
+// encoding.TextUnmarshaler.
+func isTextUnmarshaler(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(textUnmarshalerType)
+}
+
+// fieldParser returns the function to parse the input to the value
+// of type t. It returns false if t is not supported.
+func fieldParser(t reflect.Type) (func(string) (reflect.Value, error), bool) {
+	switch {
+	case isTextUnmarshaler(t):
+		return func(s string) (reflect.Value, error) {
+			v := reflect.New(t)
+			if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s)); err != nil {
+				return reflect.Value{}, err
+			}
+			return v.Elem(), nil
+		}, true
+	case t == durationType:
+		return func(s string) (reflect.Value, error) {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(d), nil
+		}, true
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return func(s string) (reflect.Value, error) {
+			return reflect.ValueOf(s).Convert(t), nil
+		}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(s string) (reflect.Value, error) {
+			n, err := strconv.ParseInt(s, 10, t.Bits())
+			if err != nil {
+				return reflect.Value{}, numberError(err)
+			}
+			return reflect.ValueOf(n).Convert(t), nil
+		}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(s string) (reflect.Value, error) {
+			n, err := strconv.ParseUint(s, 10, t.Bits())
+			if err != nil {
+				return reflect.Value{}, numberError(err)
+			}
+			return reflect.ValueOf(n).Convert(t), nil
+		}, true
+	case reflect.Float32, reflect.Float64:
+		return func(s string) (reflect.Value, error) {
+			n, err := strconv.ParseFloat(s, t.Bits())
+			if err != nil {
+				return reflect.Value{}, numberError(err)
+			}
+			return reflect.ValueOf(n).Convert(t), nil
+		}, true
+	}
+
+	return nil, false
+}
+
+// numberError converts the error of strconv to ErrNotNumber or
+// ErrOutOfRange.
+func numberError(err error) error {
+	if e, ok := err.(*strconv.NumError); ok && e.Err == strconv.ErrRange {
+		return ErrOutOfRange
+	}
+
+	return ErrNotNumber
+}
+
+// formatField formats the value of the field to display it as the
+// default value.
+func formatField(v reflect.Value) string {
+	if v.Kind() == reflect.Slice && !isTextUnmarshaler(v.Type()) {
+		values := make([]string, v.Len())
+		for n := range values {
+			values[n] = formatField(v.Index(n))
+		}
+
+		return strings.Join(values, ",")
+	}
+
+	if reflect.PtrTo(v.Type()).Implements(textMarshalerType) {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		if text, err := p.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,263 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Name    string        `input:"name,required" prompt:"What is your name?"`
+	Age     int8          `prompt:"How old are you?"`
+	Apply   bool          `prompt:"Apply?" default:"yes"`
+	Region  string        `choices:"osaka,tokyo"`
+	Tags    []string      `input:"tags"`
+	Ports   []uint16      `choices:"80,443,8080"`
+	Timeout time.Duration `default:"30s"`
+	Ratio   float64
+	IP      net.IP
+	DB      struct {
+		Host string `default:"localhost"`
+		Port int
+	}
+	Cache *struct {
+		Size uint
+	}
+	Skip     string `input:"-"`
+	internal string
+}
+
+func TestPopulate(t *testing.T) {
+	var cfg testConfig
+	cfg.DB.Port = 5432
+	cfg.Skip = "skip"
+
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString(
+			"tcnksm\n" + // Name
+				"30\n" + // Age
+				"\n" + // Apply
+				"2\n" + // Region
+				"a, b\n" + // Tags
+				"2,1\n" + // Ports
+				"\n" + // Timeout
+				"0.5\n" + // Ratio
+				"127.0.0.1\n" + // IP
+				"\n" + // DB.Host
+				"\n" + // DB.Port
+				"64\n", // Cache.Size
+		),
+	}
+
+	if err := ui.Populate(&cfg); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if cfg.Name != "tcnksm" || cfg.Age != 30 || !cfg.Apply || cfg.Region != "tokyo" {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+
+	if !reflect.DeepEqual(cfg.Tags, []string{"a", "b"}) {
+		t.Fatalf("expect %q to be eq %q", cfg.Tags, []string{"a", "b"})
+	}
+
+	if !reflect.DeepEqual(cfg.Ports, []uint16{80, 443}) {
+		t.Fatalf("expect %v to be eq %v", cfg.Ports, []uint16{80, 443})
+	}
+
+	if cfg.Timeout != 30*time.Second || cfg.Ratio != 0.5 || !cfg.IP.Equal(net.IPv4(127, 0, 0, 1)) {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+
+	if cfg.DB.Host != "localhost" || cfg.DB.Port != 5432 {
+		t.Fatalf("unexpected result: %+v", cfg.DB)
+	}
+
+	if cfg.Cache == nil || cfg.Cache.Size != 64 {
+		t.Fatalf("unexpected result: %+v", cfg.Cache)
+	}
+
+	if cfg.Skip != "skip" {
+		t.Fatalf("expect %q to be eq %q", cfg.Skip, "skip")
+	}
+
+	// The prompts are the tags or the paths of the fields
+	for _, query := range []string{"What is your name?", "How old are you?", "Region", "DB.Port", "Cache.Size"} {
+		if !bytes.Contains(out.Bytes(), []byte(query)) {
+			t.Fatalf("expect %q to be displayed", query)
+		}
+	}
+}
+
+func TestPopulate_empty(t *testing.T) {
+	var cfg struct {
+		Port    int
+		Timeout time.Duration
+		IP      net.IP
+		Tags    []string
+	}
+
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("\n\n\n\n"),
+	}
+
+	// The optional fields which are not input are left as they are
+	if err := ui.Populate(&cfg); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if cfg.Port != 0 || cfg.Timeout != 0 || cfg.IP != nil || cfg.Tags != nil {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+}
+
+func TestPopulate_mask(t *testing.T) {
+	var cfg struct {
+		Password string   `mask:"true" default:"hunter2secret"`
+		Token    string   `mask:"true"`
+		Keys     []string `mask:"true"`
+	}
+	cfg.Token = "t0ken-value"
+	cfg.Keys = []string{"k3y-value"}
+
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString("\n\n\n"),
+	}
+
+	if err := ui.Populate(&cfg); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if cfg.Password != "hunter2secret" || cfg.Token != "t0ken-value" {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+
+	// The defaults of the masked fields are not revealed
+	for _, secret := range []string{"hun", "t0k", "k3y"} {
+		if strings.Contains(out.String(), secret) {
+			t.Fatalf("expect %q not to contain %q", out.String(), secret)
+		}
+	}
+
+	expect := "Enter a value (Default is *******): "
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+}
+
+func TestPopulate_keys(t *testing.T) {
+	var cfg testConfig
+	ui := &UI{
+		Writer:         ioutil.Discard,
+		Reader:         bytes.NewBufferString(""),
+		NonInteractive: true,
+		Resolvers: []Resolver{
+			MapResolver{
+				"name":       "tcnksm",
+				"age":        "30",
+				"region":     "osaka",
+				"tags":       "a,b",
+				"ports":      "443",
+				"ratio":      "1",
+				"ip":         "::1",
+				"db.port":    "3306",
+				"cache.size": "1",
+			},
+		},
+	}
+
+	if err := ui.Populate(&cfg); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if cfg.Name != "tcnksm" || cfg.DB.Port != 3306 || cfg.Cache.Size != 1 || !reflect.DeepEqual(cfg.Ports, []uint16{443}) {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+}
+
+func TestPopulate_invalid(t *testing.T) {
+	var cfg testConfig
+	ui := &UI{
+		Writer:         ioutil.Discard,
+		Reader:         bytes.NewBufferString(""),
+		NonInteractive: true,
+		Resolvers: []Resolver{
+			MapResolver{
+				"name":    "tcnksm",
+				"age":     "300",
+				"region":  "kyoto",
+				"tags":    "a",
+				"ports":   "443",
+				"ratio":   "1",
+				"ip":      "localhost",
+				"db.port": "3306",
+			},
+		},
+	}
+
+	err := ui.Populate(&cfg)
+
+	var perr *PopulateError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expect %v to be PopulateError", err)
+	}
+
+	var fields []string
+	for _, ferr := range perr.Errors {
+		fields = append(fields, ferr.Field)
+	}
+
+	expect := []string{"Age", "Region", "IP", "Cache.Size"}
+	if !reflect.DeepEqual(fields, expect) {
+		t.Fatalf("expect %q to be eq %q", fields, expect)
+	}
+
+	if !errors.Is(err, ErrOutOfRange) || !errors.Is(err, ErrNonInteractive) {
+		t.Fatalf("expect %v to describe every error", err)
+	}
+
+	if perr.Errors[3].Key != "cache.size" {
+		t.Fatalf("expect %q to be eq %q", perr.Errors[3].Key, "cache.size")
+	}
+
+	// The valid fields are set
+	if cfg.Name != "tcnksm" || cfg.DB.Port != 3306 {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+}
+
+func TestPopulate_interrupted(t *testing.T) {
+	var cfg testConfig
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("tcnksm\n"),
+	}
+
+	if err := ui.Populate(&cfg); err != ErrEOF {
+		t.Fatalf("expect %v to be eq %v", err, ErrEOF)
+	}
+}
+
+func TestPopulate_notStruct(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString(""),
+	}
+
+	var cfg testConfig
+	for _, v := range []interface{}{cfg, (*testConfig)(nil), new(string)} {
+		if err := ui.Populate(v); err == nil {
+			t.Fatalf("expect error to occurr for %T", v)
+		}
+	}
+}
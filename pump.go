@@ -0,0 +1,150 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+)
+
+// pumpBufSize is the size of the buffer of a read from the reader.
+const pumpBufSize = 1024
+
+// pump reads the input from the reader for UI. The read is done in
+// another goroutine so that the prompt can stop waiting for it when
+// it's interrupted or canceled. At most one read is in flight and
+// its result is kept for the next prompt even when the prompt which
+// started it has already returned, so no input is lost and the next
+// prompt sees the input which the user typed after the interruption.
+// The bytes are wiped when they are consumed so that the secret input
+// does not remain in the buffer.
+type pump struct {
+	r io.Reader
+
+	// resultCh receives the result of the read in flight.
+	resultCh chan pumpResult
+
+	// pending is true while a read is in flight.
+	pending bool
+
+	// buf is the bytes which are read but not consumed yet.
+	buf []byte
+
+	// lf is true if '\n' is skipped when it's the next byte which
+	// is read. See skipLF.
+	lf bool
+}
+
+// pumpResult is the result of a read.
+type pumpResult struct {
+	data []byte
+	err  error
+}
+
+// newPump returns the pump which reads r.
+func newPump(r io.Reader) *pump {
+	return &pump{
+		r:        r,
+		resultCh: make(chan pumpResult, 1),
+	}
+}
+
+// fill reads the reader once and appends the result to the buffer.
+// If the context is done or SIGINT is received from sigCh before the
+// read finishes, it returns ctx.Err() or ErrInterrupted and the read
+// is left in flight for the next call.
+func (p *pump) fill(ctx context.Context, sigCh <-chan os.Signal) error {
+	if !p.pending {
+		p.pending = true
+		go func() {
+			buf := make([]byte, pumpBufSize)
+			n, err := p.r.Read(buf)
+			p.resultCh <- pumpResult{data: buf[:n], err: err}
+		}()
+	}
+
+	select {
+	case <-sigCh:
+		return ErrInterrupted
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-p.resultCh:
+		p.pending = false
+		p.buf = appendWipe(p.buf, res.data)
+		wipeBytes(res.data)
+		if p.lf && len(p.buf) > 0 {
+			p.skipLF()
+		}
+		return res.err
+	}
+}
+
+// skipLF skips the next byte if it's '\n', e.g., to consume CRLF as
+// the end of the line. If no byte is read yet, it's skipped when it's
+// read without waiting for it.
+func (p *pump) skipLF() {
+	if len(p.buf) == 0 {
+		p.lf = true
+		return
+	}
+
+	p.lf = false
+	if p.buf[0] == '\n' {
+		p.buf[0] = 0
+		p.buf = p.buf[1:]
+	}
+}
+
+// readByte reads a single byte.
+func (p *pump) readByte(ctx context.Context, sigCh <-chan os.Signal) (byte, error) {
+	for len(p.buf) == 0 {
+		if err := p.fill(ctx, sigCh); err != nil && len(p.buf) == 0 {
+			return 0, err
+		}
+	}
+
+	b := p.buf[0]
+	p.buf[0] = 0
+	p.buf = p.buf[1:]
+	return b, nil
+}
+
+// readLine reads until the first '\n' and returns the line including
+// it. If the reader returns io.EOF before '\n', it returns the rest
+// of the input with io.EOF.
+func (p *pump) readLine(ctx context.Context, sigCh <-chan os.Signal) (string, error) {
+	for {
+		if i := bytes.IndexByte(p.buf, '\n'); i >= 0 {
+			line := string(p.buf[:i+1])
+			wipeBytes(p.buf[:i+1])
+			p.buf = p.buf[i+1:]
+			return line, nil
+		}
+
+		if err := p.fill(ctx, sigCh); err != nil {
+			// Keep the partial line for the next prompt when the
+			// prompt is stopped while the read is in flight.
+			if p.pending {
+				return "", err
+			}
+
+			line := string(p.buf)
+			wipeBytes(p.buf)
+			p.buf = nil
+			return line, err
+		}
+	}
+}
+
+// appendWipe appends src to dst same as append, but if dst is grown,
+// the old one is wiped.
+func appendWipe(dst, src []byte) []byte {
+	if len(dst)+len(src) <= cap(dst) {
+		return append(dst, src...)
+	}
+
+	b := make([]byte, len(dst), 2*cap(dst)+len(src))
+	copy(b, dst)
+	wipeBytes(dst)
+	return append(b, src...)
+}
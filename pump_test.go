@@ -0,0 +1,104 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestPump_readLine(t *testing.T) {
+	p := newPump(bytes.NewBufferString("taichi\nnakashima"))
+
+	expect := []struct {
+		line string
+		err  error
+	}{
+		{line: "taichi\n"},
+		{line: "nakashima", err: io.EOF},
+		{line: "", err: io.EOF},
+	}
+
+	for i, e := range expect {
+		line, err := p.readLine(context.Background(), nil)
+		if err != e.err {
+			t.Fatalf("#%d expect %v to be eq %v", i, err, e.err)
+		}
+
+		if line != e.line {
+			t.Fatalf("#%d expect %q to be eq %q", i, line, e.line)
+		}
+	}
+}
+
+func TestAsk_afterInterrupt(t *testing.T) {
+	sigChs := make(chan chan<- os.Signal, 1)
+	defer func(f func(chan<- os.Signal)) { notifyInterrupt = f }(notifyInterrupt)
+	notifyInterrupt = func(c chan<- os.Signal) {
+		sigChs <- c
+	}
+
+	r, w := io.Pipe()
+	defer w.Close()
+
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: r,
+	}
+
+	// Interrupt the prompt while it's waiting for the input
+	go func() {
+		c := <-sigChs
+		c <- os.Interrupt
+	}()
+
+	if _, err := ui.Ask("", &Options{}); err != ErrInterrupted {
+		t.Fatalf("expect %v to be eq %v", err, ErrInterrupted)
+	}
+
+	// The input must be read by the next prompt, not by the reading
+	// left by the interrupted prompt.
+	go func() {
+		<-sigChs
+		w.Write([]byte("tcnksm\n"))
+	}()
+
+	ans, err := ui.Ask("", &Options{})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if ans != "tcnksm" {
+		t.Fatalf("expect %q to be eq %q", ans, "tcnksm")
+	}
+}
+
+func TestAskContext_afterCancel(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: r,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := ui.AskContext(ctx, "", &Options{}); err != context.Canceled {
+		t.Fatalf("expect %v to be eq %v", err, context.Canceled)
+	}
+
+	go w.Write([]byte("tcnksm\n"))
+
+	ans, err := ui.Ask("", &Options{})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if ans != "tcnksm" {
+		t.Fatalf("expect %q to be eq %q", ans, "tcnksm")
+	}
+}
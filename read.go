@@ -1,6 +1,7 @@
 package input
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -13,80 +14,113 @@ type readOptions struct {
 	// mask hides user input and will be matched by maskVal.
 	mask    bool
 	maskVal string
+
+	// meter displays the strength of the masked input.
+	meter bool
 }
 
 // read reads input from UI.Reader
 func (i *UI) read(opts *readOptions) (string, error) {
-	i.once.Do(i.setDefault)
+	return i.readContext(context.Background(), opts)
+}
+
+// readContext reads input from UI.Reader same as read, but returns
+// ctx.Err() when the context is done before the input is read.
+func (i *UI) readContext(ctx context.Context, opts *readOptions) (string, error) {
+	if err := i.setup(); err != nil {
+		return "", err
+	}
 
 	// sigCh is channel which is watch Interruptted signal (SIGINT)
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt)
+	notifyInterrupt(sigCh)
 	defer signal.Stop(sigCh)
 
-	var resultStr string
-	var resultErr error
-	doneCh := make(chan struct{})
-
-	go func() {
-		defer close(doneCh)
-
-		if opts.mask {
-			f, ok := i.Reader.(*os.File)
-			if !ok {
-				resultErr = fmt.Errorf("reader must be a file")
-				return
+	if opts.mask {
+		// If the reader is not a terminal, the input is not echoed
+		// and the mask is written without raw mode.
+		t := i.terminal()
+		if t != nil {
+			if err := t.MakeRaw(); err != nil {
+				return "", err
 			}
+			defer t.Restore()
+		}
 
-			i.mask, i.maskVal = opts.mask, opts.maskVal
-			resultStr, resultErr = i.rawRead(f)
-		} else {
-			line, err := i.bReader.ReadString('\n')
-			if err != nil && err != io.EOF {
-				resultErr = fmt.Errorf("failed to read the input: %s", err)
-			}
+		i.mask, i.maskVal = opts.mask, opts.maskVal
+		i.meter = opts.meter && t != nil
+		i.crlf = t == nil
+		return i.rawReadline(ctx, sigCh)
+	}
 
-			resultStr = strings.TrimSuffix(line, LineSep)
-			// brute force for the moment
-			resultStr = strings.TrimSuffix(line, "\n")
-		}
-	}()
+	line, err := i.in.readLine(ctx, sigCh)
+	if err != nil && (err == ErrInterrupted || err == ctx.Err()) {
+		return "", err
+	}
 
-	select {
-	case <-sigCh:
-		return "", ErrInterrupted
-	case <-doneCh:
-		return resultStr, resultErr
+	// Distinguish the end of the input from the empty input
+	if err == io.EOF && line == "" {
+		return "", ErrEOF
 	}
+
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read the input: %s", err)
+	}
+
+	line = strings.TrimSuffix(line, LineSep)
+	line = strings.TrimSuffix(line, "\n")
+
+	// CRLF may be sent by the reader which is not a terminal
+	return strings.TrimSuffix(line, "\r"), nil
 }
 
-// rawReadline tries to return a single line, not including the end-of-line
-// bytes with raw Mode (without prompting nothing). Or if provided show some
-// value instead of actual value.
-func (i *UI) rawReadline(f *os.File) (string, error) {
-	var resultBuf []byte
-	for {
-		var buf [1]byte
-		n, err := f.Read(buf[:])
-		if err != nil && err != io.EOF {
-			return "", err
-		}
+// readSecret reads the masked input same as readContext, but returns
+// it as the bytes. No string is made from the input and the buffers
+// are wiped, so the caller must wipe the returned bytes after use.
+func (i *UI) readSecret(ctx context.Context, opts *readOptions) ([]byte, error) {
+	if err := i.setup(); err != nil {
+		return nil, err
+	}
 
-		if n == 0 || buf[0] == '\n' || buf[0] == '\r' {
-			break
-		}
+	sigCh := make(chan os.Signal, 1)
+	notifyInterrupt(sigCh)
+	defer signal.Stop(sigCh)
 
-		if buf[0] == 3 {
-			return "", ErrInterrupted
+	t := i.terminal()
+	if t != nil {
+		if err := t.MakeRaw(); err != nil {
+			return nil, err
 		}
+		defer t.Restore()
+	}
 
-		if i.mask {
-			fmt.Fprintf(i.Writer, i.maskVal)
-		}
+	e := &lineEditor{
+		w:       i.Writer,
+		mask:    true,
+		maskVal: opts.maskVal,
+		meter:   opts.meter && t != nil,
+		crlf:    t == nil,
+	}
+
+	return e.readSecret(ctx, &keyReader{in: i.in, sigCh: sigCh})
+}
 
-		resultBuf = append(resultBuf, buf[0])
+// notifyInterrupt relays SIGINT to c. It's replaced in tests.
+var notifyInterrupt = func(c chan<- os.Signal) {
+	signal.Notify(c, os.Interrupt)
+}
+
+// rawReadline tries to return a single line, not including the end-of-line
+// bytes with raw Mode (without prompting nothing). The line can be edited
+// by lineEditor. Or if provided show some value instead of actual value.
+func (i *UI) rawReadline(ctx context.Context, sigCh <-chan os.Signal) (string, error) {
+	e := &lineEditor{
+		w:       i.Writer,
+		mask:    i.mask,
+		maskVal: i.maskVal,
+		meter:   i.meter,
+		crlf:    i.crlf,
 	}
 
-	fmt.Fprintf(i.Writer, "\n")
-	return string(resultBuf), nil
+	return e.readLine(ctx, &keyReader{in: i.in, sigCh: sigCh})
 }
@@ -9,9 +9,10 @@ import (
 
 func TestRead(t *testing.T) {
 	cases := []struct {
-		opts      *readOptions
-		userInput io.Reader
-		expect    string
+		opts        *readOptions
+		userInput   io.Reader
+		expect      string
+		expectWrite string
 	}{
 		{
 			opts: &readOptions{
@@ -31,12 +32,31 @@ func TestRead(t *testing.T) {
 			expect:    "taichi nakashima",
 		},
 
-		// No good way to test masking...
+		{
+			opts: &readOptions{
+				mask:    true,
+				maskVal: "*",
+			},
+			userInput:   bytes.NewBufferString("passw0rd\n"),
+			expect:      "passw0rd",
+			expectWrite: "********\n",
+		},
+
+		{
+			opts: &readOptions{
+				mask:    true,
+				maskVal: "",
+			},
+			userInput:   bytes.NewBufferString("passw0rd\n"),
+			expect:      "passw0rd",
+			expectWrite: "\n",
+		},
 	}
 
 	for i, tc := range cases {
+		var buf bytes.Buffer
 		ui := &UI{
-			Writer: ioutil.Discard,
+			Writer: &buf,
 			Reader: tc.userInput,
 		}
 
@@ -48,5 +68,25 @@ func TestRead(t *testing.T) {
 		if out != tc.expect {
 			t.Fatalf("#%d expect %q to be eq %q", i, out, tc.expect)
 		}
+
+		if buf.String() != tc.expectWrite {
+			t.Fatalf("#%d expect %q to be eq %q", i, buf.String(), tc.expectWrite)
+		}
+	}
+
+	// CRLF of the masked input is not left for the next prompt
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("secret\r\nname\r\n"),
+	}
+
+	out, err := ui.read(&readOptions{mask: true, maskVal: "*"})
+	if err != nil || out != "secret" {
+		t.Fatalf("expect %q to be eq %q: %v", out, "secret", err)
+	}
+
+	out, err = ui.Ask("Name?", &Options{Required: true})
+	if err != nil || out != "name" {
+		t.Fatalf("expect %q to be eq %q: %v", out, "name", err)
 	}
 }
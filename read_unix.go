@@ -12,21 +12,39 @@ import (
 // LineSep is the separator for windows or unix systems
 const LineSep = "\n"
 
-// rawRead reads file with raw mode (without prompting to terminal).
-func (i *UI) rawRead(f *os.File) (string, error) {
+// isTerminal returns true if the given file is a terminal.
+func isTerminal(f *os.File) bool {
+	return terminal.IsTerminal(int(f.Fd()))
+}
+
+// rawMode puts the terminal connected to the given file into raw mode
+// and returns the function to restore the previous state.
+func rawMode(f *os.File) (func(), error) {
 
 	// MakeRaw put the terminal connected to the given file descriptor
 	// into raw mode
 	fd := int(f.Fd())
 	if !terminal.IsTerminal(fd) {
-		return "", fmt.Errorf("file descriptor %d is not a terminal", fd)
+		return nil, fmt.Errorf("file descriptor %d is not a terminal", fd)
 	}
 
 	oldState, err := terminal.MakeRaw(fd)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return func() {
+		terminal.Restore(fd, oldState)
+	}, nil
+}
+
+// openControllingTTY opens the controlling terminal for reading and
+// writing.
+func openControllingTTY() (*os.File, *os.File, error) {
+	f, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
 	}
-	defer terminal.Restore(fd, oldState)
 
-	return i.rawReadline(f)
+	return f, f, nil
 }
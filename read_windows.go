@@ -16,36 +16,41 @@ const LineSep = "\r\n"
 // http://msdn.microsoft.com/en-us/library/windows/desktop/ms686033(v=vs.85).aspx
 const ENABLE_ECHO_INPUT = 0x0004
 
-// rawRead reads file with raw mode (without prompting to terminal).
-//
-// For this windows version of rawRead(). I referred the codes on
-// hashicorp/vault/helper and cloudfoundry/cli/terminal
-func (i *UI) rawRead(f *os.File) (string, error) {
-
-	// In windows, Handle can be used to examine or modify the system resource.
-	// https://msdn.microsoft.com/en-us/library/windows/desktop/ms724457(v=vs.85).aspx
-	handle := syscall.Handle(f.Fd())
-
-	resetFunc, err := makeRaw(handle)
-	if err != nil {
-		return "", err
-	}
-	defer resetFunc()
+// Other console input modes which are changed by rawMode.
+const (
+	enableProcessedInput       = 0x0001
+	enableLineInput            = 0x0002
+	enableVirtualTerminalInput = 0x0200
+)
 
-	return i.rawReadline(f)
+// isTerminal returns true if the given file is a console.
+func isTerminal(f *os.File) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(f.Fd()), &mode) == nil
 }
 
-func makeRaw(console syscall.Handle) (func(), error) {
+// rawMode puts the console connected to the given file into raw mode
+// where each key is read without echo (and special keys are read as
+// VT100 escape sequences) and returns the function to restore the
+// previous state.
+//
+// For this windows version of rawMode(). I referred the codes on
+// hashicorp/vault/helper and cloudfoundry/cli/terminal
+func rawMode(f *os.File) (func(), error) {
+	console := syscall.Handle(f.Fd())
 
-	// Get old mode so that we can recover later
 	var oldMode uint32
 	if err := syscall.GetConsoleMode(console, &oldMode); err != nil {
 		return nil, err
 	}
 
-	var newMode uint32 = uint32(int(oldMode) & ^ENABLE_ECHO_INPUT)
-	if err := setConsoleMode(console, newMode); err != nil {
-		return nil, err
+	newMode := oldMode &^ (ENABLE_ECHO_INPUT | enableLineInput | enableProcessedInput)
+	if err := setConsoleMode(console, newMode|enableVirtualTerminalInput); err != nil {
+		// The console before Windows 10 doesn't support VT100
+		// sequences. Special keys can't be read but the others can.
+		if err := setConsoleMode(console, newMode); err != nil {
+			return nil, err
+		}
 	}
 
 	return func() {
@@ -74,3 +79,19 @@ func setConsoleMode(console syscall.Handle, mode uint32) error {
 
 	return nil
 }
+
+// openControllingTTY opens the console input and output buffers.
+func openControllingTTY() (*os.File, *os.File, error) {
+	in, err := os.OpenFile("CONIN$", os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out, err := os.OpenFile("CONOUT$", os.O_WRONLY, 0)
+	if err != nil {
+		in.Close()
+		return nil, nil, err
+	}
+
+	return in, out, nil
+}
@@ -0,0 +1,156 @@
+package input
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Resolver resolves the answer of the prompt by its key (Options.Key)
+// before asking the user, e.g., from the environment variables or the
+// command line flags. It lets the same code run interactively and
+// unattended. See UI.Resolvers.
+type Resolver interface {
+	// Resolve returns the answer for the key. ok is false if it
+	// doesn't have the answer.
+	Resolve(key string) (value string, ok bool)
+}
+
+// ListResolver is Resolver which resolves the answer of MultiSelect as
+// the list of the labels, e.g., the list in the answers file. Without
+// it, the answer of Resolver is split by comma.
+type ListResolver interface {
+	Resolver
+
+	// ResolveList returns the labels for the key. The empty list is
+	// the empty selection. ok is false if it doesn't have the answer.
+	ResolveList(key string) (labels []string, ok bool)
+}
+
+// ResolverFunc is an adapter to use the function as Resolver.
+type ResolverFunc func(key string) (string, bool)
+
+// Resolve calls f(key).
+func (f ResolverFunc) Resolve(key string) (string, bool) {
+	return f(key)
+}
+
+// MapResolver resolves the answers by the given values.
+type MapResolver map[string]string
+
+// Resolve returns the value of the key.
+func (m MapResolver) Resolve(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// EnvResolver resolves the answers by the environment variables.
+// The name of the variable is Prefix followed by the key which is
+// upper-cased and whose characters other than letters and digits
+// are replaced with underscore, e.g., "APP_DB_HOST" for "db.host"
+// with the prefix "APP_".
+type EnvResolver struct {
+	Prefix string
+}
+
+// Resolve returns the value of the environment variable of the key.
+func (r *EnvResolver) Resolve(key string) (string, bool) {
+	return os.LookupEnv(r.Name(key))
+}
+
+// Name returns the name of the environment variable of the key.
+func (r *EnvResolver) Name(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, key)
+
+	return r.Prefix + name
+}
+
+// FlagResolver resolves the answers by the flags of FlagSet whose
+// names are same as the keys. Only the flags which are set on the
+// command line are used, so the default values of the flags don't
+// prevent asking the user.
+type FlagResolver struct {
+	FlagSet *flag.FlagSet
+}
+
+// Resolve returns the value of the flag of the key if it's set.
+func (r *FlagResolver) Resolve(key string) (string, bool) {
+	var value string
+	var ok bool
+	r.FlagSet.Visit(func(f *flag.Flag) {
+		if f.Name == key {
+			value, ok = f.Value.String(), true
+		}
+	})
+
+	return value, ok
+}
+
+// resolve returns the answer for the key from UI.Resolvers. The first
+// non-empty answer is used.
+func (i *UI) resolve(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+
+	for _, r := range i.Resolvers {
+		if v, ok := r.Resolve(key); ok && v != "" {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// resolveList returns the labels for the key from UI.Resolvers. The
+// answer of ListResolver is used as it is even if it's empty, and the
+// first non-empty answer of the other resolvers is split by comma.
+func (i *UI) resolveList(key string) ([]string, bool) {
+	if key == "" {
+		return nil, false
+	}
+
+	for _, r := range i.Resolvers {
+		if lr, ok := r.(ListResolver); ok {
+			if v, ok := lr.ResolveList(key); ok {
+				return v, true
+			}
+			continue
+		}
+
+		if v, ok := r.Resolve(key); ok && v != "" {
+			return splitList(v), true
+		}
+	}
+
+	return nil, false
+}
+
+// splitList splits the answer into the labels by comma.
+func splitList(s string) []string {
+	labels := strings.Split(s, ",")
+	for n, label := range labels {
+		labels[n] = strings.TrimSpace(label)
+	}
+
+	return labels
+}
+
+// rejectResolved handles the resolved answer for opts.Key which is
+// rejected by err. If the user can be asked instead, it tells the
+// user why and returns nil. Otherwise it returns the error.
+func (i *UI) rejectResolved(opts *Options, err error) error {
+	if !opts.Loop || i.nonInteractive() {
+		return fmt.Errorf("invalid answer for %q: %w", opts.Key, err)
+	}
+
+	fmt.Fprintf(i.Writer, "Failed to validate the answer for %q: %s\n\n", opts.Key, err)
+	return nil
+}
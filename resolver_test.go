@@ -0,0 +1,156 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestEnvResolver(t *testing.T) {
+	t.Setenv("APP_DB_HOST", "localhost")
+
+	cases := []struct {
+		key      string
+		expect   string
+		expectOK bool
+	}{
+		{key: "db.host", expect: "localhost", expectOK: true},
+		{key: "DB_HOST", expect: "localhost", expectOK: true},
+		{key: "db-port", expectOK: false},
+	}
+
+	r := &EnvResolver{Prefix: "APP_"}
+	for i, c := range cases {
+		v, ok := r.Resolve(c.key)
+		if v != c.expect || ok != c.expectOK {
+			t.Fatalf("#%d expect (%q, %v) to be eq (%q, %v)", i, v, ok, c.expect, c.expectOK)
+		}
+	}
+}
+
+func TestFlagResolver(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("name", "", "")
+	fs.String("region", "us-east-1", "")
+	if err := fs.Parse([]string{"-name", "tcnksm"}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &FlagResolver{FlagSet: fs}
+	if v, ok := r.Resolve("name"); v != "tcnksm" || !ok {
+		t.Fatalf("expect (%q, %v) to be eq (%q, %v)", v, ok, "tcnksm", true)
+	}
+
+	// The default value of the flag is not used
+	if _, ok := r.Resolve("region"); ok {
+		t.Fatalf("expect the flag which is not set not to be resolved")
+	}
+}
+
+func TestUI_Resolvers(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+		// The user is never asked
+		Reader:         bytes.NewBufferString(""),
+		NonInteractive: true,
+		Resolvers: []Resolver{
+			MapResolver{"name": ""},
+			ResolverFunc(func(key string) (string, bool) {
+				return map[string]string{
+					"name":    "tcnksm",
+					"age":     "30",
+					"apply":   "yes",
+					"region":  "tokyo",
+					"regions": "osaka, tokyo",
+				}[key], true
+			}),
+		},
+	}
+
+	name, err := ui.Ask("What is your name?", &Options{Key: "name", Default: "taichi"})
+	if err != nil || name != "tcnksm" {
+		t.Fatalf("expect %q to be eq %q: %v", name, "tcnksm", err)
+	}
+
+	age, err := ui.AskInt("How old are you?", &IntRange{Min: intp(0), Max: intp(200)}, &Options{Key: "age"})
+	if err != nil || age != 30 {
+		t.Fatalf("expect %d to be eq %d: %v", age, 30, err)
+	}
+
+	apply, err := ui.Confirm("Apply?", &ConfirmOptions{Key: "apply"})
+	if err != nil || !apply {
+		t.Fatalf("expect %v to be eq %v: %v", apply, true, err)
+	}
+
+	region, err := ui.Select("Which region?", []string{"osaka", "tokyo"}, &Options{Key: "region"})
+	if err != nil || region != "tokyo" {
+		t.Fatalf("expect %q to be eq %q: %v", region, "tokyo", err)
+	}
+
+	regions, err := ui.MultiSelect("Which regions?", []string{"osaka", "tokyo", "nagoya"}, &MultiSelectOptions{
+		Options: Options{Key: "regions"},
+	})
+	if err != nil || !reflect.DeepEqual(regions, []string{"osaka", "tokyo"}) {
+		t.Fatalf("expect %q to be eq %q: %v", regions, []string{"osaka", "tokyo"}, err)
+	}
+}
+
+func TestUI_Resolvers_invalid(t *testing.T) {
+	errInvalid := fmt.Errorf("name must not be root")
+	validate := func(s string) error {
+		if s == "root" {
+			return errInvalid
+		}
+		return nil
+	}
+
+	cases := []struct {
+		opts      *Options
+		expect    string
+		expectErr error
+	}{
+		{
+			opts:      &Options{Key: "name", ValidateFunc: validate},
+			expectErr: errInvalid,
+		},
+
+		// The user is asked instead
+		{
+			opts:   &Options{Key: "name", ValidateFunc: validate, Loop: true},
+			expect: "tcnksm",
+		},
+	}
+
+	for i, c := range cases {
+		var out bytes.Buffer
+		ui := &UI{
+			Writer:    &out,
+			Reader:    bytes.NewBufferString("tcnksm\n"),
+			Resolvers: []Resolver{MapResolver{"name": "root"}},
+		}
+
+		ans, err := ui.Ask("What is your name?", c.opts)
+		if !errors.Is(err, c.expectErr) || (err == nil) != (c.expectErr == nil) {
+			t.Fatalf("#%d expect %v to be %v", i, err, c.expectErr)
+		}
+
+		if ans != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, ans, c.expect)
+		}
+	}
+
+	// The item which is not in the list
+	ui := &UI{
+		Writer:    ioutil.Discard,
+		Reader:    bytes.NewBufferString("1\n"),
+		Resolvers: []Resolver{MapResolver{"region": "kyoto"}},
+	}
+
+	if _, err := ui.Select("Which region?", []string{"osaka", "tokyo"}, &Options{Key: "region"}); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("expect %v to be %v", err, ErrOutOfRange)
+	}
+}
@@ -0,0 +1,129 @@
+package input
+
+import (
+	"context"
+	"fmt"
+)
+
+// Secret is the secret input which AskSecret returns. Unlike string,
+// it can be wiped from the memory after use.
+type Secret struct {
+	b []byte
+}
+
+// Bytes returns the secret. It's not copied, so it's wiped by Wipe.
+// It returns nil after Wipe.
+func (s *Secret) Bytes() []byte {
+	return s.b
+}
+
+// Wipe overwrites the secret with zeros and releases it.
+func (s *Secret) Wipe() {
+	wipeBytes(s.b)
+	s.b = nil
+}
+
+// String implements fmt.Stringer. It returns the mask, not the secret,
+// so the secret is not printed by mistake. The methods which format
+// Secret have the value receivers so that both Secret and *Secret are
+// masked.
+func (s Secret) String() string {
+	return maskString("")
+}
+
+// GoString implements fmt.GoStringer. It returns the mask same as
+// String.
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// Format implements fmt.Formatter. It writes the mask for any verb,
+// e.g., %x or %d which don't use String.
+func (s Secret) Format(f fmt.State, verb rune) {
+	fmt.Fprint(f, s.String())
+}
+
+// AskSecret asks the user for the secret (e.g., the key material) with
+// masking same as Ask with Mask, but returns it as Secret, which the
+// caller should wipe after use. The input is read as the bytes without
+// making string and the buffers which are used to read it are wiped.
+//
+// Default, MaskDefault, ValidateFunc and ValidateAttemptFunc are not
+// used because they need the secret as string. If Required is true,
+// the empty input is rejected. If Loop is true, it continue to ask
+// until it receives the input or the input is rejected MaxAttempts
+// times. The resolved answer (see UI.Resolvers) is used as it is,
+// which is already a string. StrengthMeter is supported.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func (i *UI) AskSecret(query string, opts *Options) (*Secret, error) {
+	if err := i.setup(); err != nil {
+		return nil, err
+	}
+
+	if resolved, ok := i.resolve(opts.Key); ok {
+		return &Secret{b: []byte(resolved)}, nil
+	}
+
+	if i.nonInteractive() {
+		return nil, &NonInteractiveError{Query: query}
+	}
+
+	// Don't modify the given options
+	o := *opts
+	if !o.Hide {
+		o.Mask = true
+	}
+
+	// Display the query to the user.
+	fmt.Fprintf(i.Writer, "%s", query)
+
+	// attempt is incremented when the input is rejected
+	attempt := 1
+	loopCount := 0
+	for {
+		loopCount++
+
+		// Display the instruction to user and ask to input.
+		if !o.HideOrder || loopCount > 1 {
+			fmt.Fprint(i.Writer, "\nEnter a value")
+		}
+		fmt.Fprint(i.Writer, ": ")
+
+		b, err := i.readSecret(context.Background(), o.readOpts())
+		if err != nil {
+			fmt.Fprintf(i.Writer, "\n")
+			return nil, err
+		}
+
+		if len(b) == 0 && o.Required {
+			if err := o.giveUp(attempt, ErrEmpty); err != nil {
+				fmt.Fprintf(i.Writer, "\n")
+				return nil, err
+			}
+
+			attempt++
+			fmt.Fprintf(i.Writer, "Input must not be empty.\n\n")
+			continue
+		}
+
+		// Insert the new line for next output
+		fmt.Fprintf(i.Writer, "\n")
+		return &Secret{b: b}, nil
+	}
+}
+
+// wipeBytes overwrites b with zeros.
+func wipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// wipeRunes overwrites rs with zeros.
+func wipeRunes(rs []rune) {
+	for i := range rs {
+		rs[i] = 0
+	}
+}
@@ -0,0 +1,207 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestAskSecret(t *testing.T) {
+	cases := []struct {
+		opts      *Options
+		userInput string
+		expect    string
+		expectErr error
+	}{
+		{
+			opts:      &Options{},
+			userInput: "s3cr3t\n",
+			expect:    "s3cr3t",
+		},
+
+		{
+			opts:      &Options{},
+			userInput: "パスワ\x7fド\n",
+			expect:    "パスド",
+		},
+
+		{
+			opts:      &Options{},
+			userInput: "\n",
+			expect:    "",
+		},
+
+		{
+			opts:      &Options{Required: true},
+			userInput: "\n",
+			expectErr: ErrEmpty,
+		},
+
+		{
+			opts:      &Options{Required: true, Loop: true},
+			userInput: "\n\ns3cr3t\n",
+			expect:    "s3cr3t",
+		},
+
+		{
+			opts:      &Options{},
+			userInput: "s3cr3t\x03",
+			expectErr: ErrInterrupted,
+		},
+
+		{
+			opts:      &Options{},
+			userInput: "",
+			expectErr: ErrEOF,
+		},
+	}
+
+	for i, c := range cases {
+		var out bytes.Buffer
+		ui := &UI{
+			Writer: &out,
+			Reader: bytes.NewBufferString(c.userInput),
+		}
+
+		secret, err := ui.AskSecret("Key?", c.opts)
+		if err != c.expectErr {
+			t.Fatalf("#%d expect %v to be eq %v", i, err, c.expectErr)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if !bytes.Equal(secret.Bytes(), []byte(c.expect)) {
+			t.Fatalf("#%d expect %q to be eq %q", i, secret.Bytes(), c.expect)
+		}
+
+		if c.expect != "" && strings.Contains(out.String(), c.expect) {
+			t.Fatalf("#%d expect the secret not to be displayed: %q", i, out.String())
+		}
+	}
+}
+
+func TestAskSecret_resolved(t *testing.T) {
+	ui := &UI{
+		Writer:         ioutil.Discard,
+		Reader:         bytes.NewBufferString(""),
+		NonInteractive: true,
+		Resolvers:      []Resolver{MapResolver{"key": "s3cr3t"}},
+	}
+
+	secret, err := ui.AskSecret("Key?", &Options{Key: "key"})
+	if err != nil || !bytes.Equal(secret.Bytes(), []byte("s3cr3t")) {
+		t.Fatalf("expect %q to be eq %q: %v", secret.Bytes(), "s3cr3t", err)
+	}
+
+	if _, err := ui.AskSecret("Key?", &Options{}); !errors.Is(err, ErrNonInteractive) {
+		t.Fatalf("expect %v to be %v", err, ErrNonInteractive)
+	}
+}
+
+func TestSecret_Format(t *testing.T) {
+	secret := &Secret{b: []byte("s3cr3t")}
+
+	// Neither Secret nor *Secret is printed by any verb
+	for _, v := range []interface{}{secret, *secret} {
+		for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d"} {
+			if s := fmt.Sprintf(format, v); s != maskString("") {
+				t.Fatalf("expect %s of %T to be eq %q: %q", format, v, maskString(""), s)
+			}
+		}
+	}
+}
+
+func TestSecret_Wipe(t *testing.T) {
+	secret := &Secret{b: []byte("s3cr3t")}
+	if s := fmt.Sprint(secret); strings.Contains(s, "s3cr3t") {
+		t.Fatalf("expect %q not to contain the secret", s)
+	}
+
+	b := secret.Bytes()
+	secret.Wipe()
+
+	if !bytes.Equal(b, make([]byte, len(b))) {
+		t.Fatalf("expect %q to be wiped", b)
+	}
+
+	if secret.Bytes() != nil {
+		t.Fatalf("expect %q to be nil", secret.Bytes())
+	}
+}
+
+func TestLineEditor_wipe(t *testing.T) {
+	e := &lineEditor{w: ioutil.Discard, mask: true, maskVal: "*"}
+
+	// The old line is wiped when it's grown
+	for _, r := range "0123456789abcdef" {
+		e.insert(r)
+	}
+	old := e.line[:cap(e.line)]
+	e.insert('g')
+
+	if !isZeroRunes(old) {
+		t.Fatalf("expect %q to be wiped", string(old))
+	}
+
+	// The deleted runes are wiped
+	line := e.line[:cap(e.line)]
+	e.delete(0, 10)
+
+	if !isZeroRunes(line[len(e.line):]) {
+		t.Fatalf("expect %q to be wiped", string(line[len(e.line):]))
+	}
+
+	// The line is wiped after it's read as the secret
+	line = e.line[:cap(e.line)]
+	b, err := e.readSecret(context.Background(), &keyReader{in: newPump(bytes.NewBufferString("\r"))})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if string(b) != "abcdefg" {
+		t.Fatalf("expect %q to be eq %q", b, "abcdefg")
+	}
+
+	if !isZeroRunes(line) {
+		t.Fatalf("expect %q to be wiped", string(line))
+	}
+}
+
+func TestPump_wipe(t *testing.T) {
+	p := newPump(bytes.NewBufferString("s3cr3t\nname\n"))
+	if err := p.fill(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	data := p.buf
+
+	for n := 0; n < 7; n++ {
+		if _, err := p.readByte(context.Background(), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := p.readLine(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, make([]byte, len(data))) {
+		t.Fatalf("expect %q to be wiped", data)
+	}
+}
+
+// isZeroRunes returns true if all runes are zero.
+func isZeroRunes(rs []rune) bool {
+	for _, r := range rs {
+		if r != 0 {
+			return false
+		}
+	}
+
+	return true
+}
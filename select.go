@@ -2,8 +2,12 @@ package input
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
+	"strings"
 )
 
 // Select asks the user to select a item from the given list by the number.
@@ -12,11 +16,136 @@ import (
 // out of range of the list and if not returns error. If Loop is true, it continue to
 // ask until it receives valid input.
 //
+// If Loop is true, the input which is not a number is used to narrow the
+// list by fuzzy matching and the matched items are shown with their numbers.
+//
+// If PageSize is more than 0, the list is displayed by the page and
+// the user turns the page by entering n or p. The items keep the numbers.
+//
+// If Interactive is true and Reader is a terminal, the user selects the item
+// by moving the cursor instead of entering the number. If Filter is also true,
+// the user can narrow the list by typing.
+//
 // If the user sends SIGINT (Ctrl+C) while reading input, it catches
 // it and return it as a error.
 func (i *UI) Select(query string, list []string, opts *Options) (string, error) {
+	return i.SelectContext(context.Background(), query, list, opts)
+}
+
+// SelectContext asks the user to select a item same as Select, but returns
+// ctx.Err() when the context is done before the user answers. If the deadline
+// of the context is exceeded and DefaultOnTimeout is true, it returns Default
+// instead.
+func (i *UI) SelectContext(ctx context.Context, query string, list []string, opts *Options) (string, error) {
+	n, err := i.selectIndex(ctx, query, newItems(list), opts)
+	if err != nil {
+		return "", err
+	}
+
+	return list[n], nil
+}
+
+// SelectIndex asks the user to select a item from the given list same
+// as Select, but returns the index of the selected item. It's useful
+// when the list has the duplicated items.
+func (i *UI) SelectIndex(query string, list []string, opts *Options) (int, error) {
+	return i.selectIndex(context.Background(), query, newItems(list), opts)
+}
+
+// SelectItems asks the user to select an item from the given items same
+// as Select and returns the index of the selected item. Items can be
+// disabled and can be the headings of the groups of items. Headings
+// are not numbered, so the numbers are not same as the indexes. If the
+// user selects the disabled item, the reason is displayed and it
+// returns ErrDisabled or continues to ask when Loop is true.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func (i *UI) SelectItems(query string, items []Item, opts *Options) (int, error) {
+	return i.selectIndex(context.Background(), query, items, opts)
+}
+
+// Item is an item of the list which SelectItems asks the user to
+// select from.
+type Item struct {
+	// Label is displayed as the item.
+	Label string
+
+	// Description is displayed in the second column next to the label.
+	Description string
+
+	// Disabled items are displayed but can not be selected, even as
+	// Default. Reason is displayed when the user selects it.
+	Disabled bool
+	Reason   string
+
+	// Heading makes the item the heading of the following items.
+	// It's not numbered and can not be selected.
+	Heading bool
+}
+
+// newItems returns the items which have the given labels.
+func newItems(labels []string) []Item {
+	items := make([]Item, len(labels))
+	for i, label := range labels {
+		items[i] = Item{Label: label}
+	}
+
+	return items
+}
+
+// labels returns the labels of the items.
+func labels(items []Item) []string {
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.Label
+	}
+
+	return labels
+}
+
+// numberItems returns the number of each item which is used to select
+// it and the indexes of the items by the number (the index of numbers
+// is the number minus 1). Headings are not numbered, i.e., 0.
+func numberItems(items []Item) ([]int, []int) {
+	numbers := make([]int, len(items))
+	var indexes []int
+	for i, item := range items {
+		if item.Heading {
+			continue
+		}
+
+		indexes = append(indexes, i)
+		numbers[i] = len(indexes)
+	}
+
+	return numbers, indexes
+}
+
+// disabledMessage returns the message which is displayed when the
+// disabled item is selected.
+func disabledMessage(item Item) string {
+	if item.Reason == "" {
+		return fmt.Sprintf("%q can not be selected.", item.Label)
+	}
+
+	return fmt.Sprintf("%q can not be selected: %s", item.Label, item.Reason)
+}
+
+// selectIndex is the implementation of Select. It returns the index
+// of the selected item.
+func (i *UI) selectIndex(ctx context.Context, query string, list []Item, opts *Options) (index int, err error) {
 	// Set default val
-	i.once.Do(i.setDefault)
+	if err := i.setup(); err != nil {
+		return -1, err
+	}
+
+	// The item is recorded by the label
+	defer func() {
+		if err == nil {
+			i.recordAnswer(opts, list[index].Label)
+		}
+	}()
 
 	// Input must not be empty if no default is specified.
 	// Because Select ask user to input by number.
@@ -27,33 +156,91 @@ func (i *UI) Select(query string, list []string, opts *Options) (string, error)
 	defaultIndex := -1
 	defaultVal := opts.Default
 	if defaultVal != "" {
+		found := false
 		for i, item := range list {
-			if item == defaultVal {
+			if item.Label != defaultVal || item.Heading {
+				continue
+			}
+
+			// The disabled item can not be selected even by default
+			found = true
+			if !item.Disabled {
 				defaultIndex = i
 			}
 		}
 
 		// DefaultVal is set but doesn't exist in list
+		if !found {
+			// This error message is not for user
+			// Should be found while development
+			return -1, fmt.Errorf("opt.Default is specified but item does not exist in list")
+		}
+
 		if defaultIndex == -1 {
 			// This error message is not for user
 			// Should be found while development
-			return "", fmt.Errorf("opt.Default is specified but item does not exist in list")
+			return -1, fmt.Errorf("opt.Default is specified but item is disabled")
+		}
+	}
+
+	// Use the resolved item without asking if it's valid
+	if resolved, ok := i.resolve(opts.Key); ok {
+		n, err := resolveItem(list, resolved, opts)
+		if err == nil {
+			return n, nil
+		}
+
+		if err := i.rejectResolved(opts, err); err != nil {
+			return -1, err
+		}
+	}
+
+	// Use the default item without asking in non-interactive mode
+	if i.nonInteractive() {
+		if defaultIndex < 0 {
+			return -1, &NonInteractiveError{Query: query}
 		}
+
+		return defaultIndex, nil
+	}
+
+	// Select by the cursor if the reader is a terminal
+	if t := i.terminal(); t != nil && opts.Interactive {
+		n, err := i.selectInteractive(ctx, t, query, list, defaultIndex, opts)
+		if err == context.DeadlineExceeded && opts.DefaultOnTimeout && defaultIndex >= 0 {
+			return defaultIndex, nil
+		}
+
+		return n, err
+	}
+
+	// Descriptions are dimmed on the terminal
+	dim := isTerminalWriter(i.Writer)
+
+	// Headings are not numbered, so the numbers can be different from
+	// the indexes of list.
+	numbers, numbered := numberItems(list)
+
+	// listing is the indexes of the items which are displayed and
+	// page is the page of them which is displayed now.
+	listing := make([]int, len(list))
+	for i := range listing {
+		listing[i] = i
 	}
+	page := 0
 
 	// Construct the query & display it to user
 	var buf bytes.Buffer
 	buf.WriteString(fmt.Sprintf("%s\n\n", query))
-	for i, item := range list {
-		buf.WriteString(fmt.Sprintf("%d. %s\n", i+1, item))
-	}
-
-	buf.WriteString("\n")
-	fmt.Fprintf(i.Writer, buf.String())
+	writeItems(&buf, list, numbers, listing, page, opts.PageSize, dim)
+	fmt.Fprint(i.Writer, buf.String())
 
-	// resultStr and resultErr are return val of this function
-	var resultStr string
+	// resultIndex and resultErr are return val of this function
+	resultIndex := -1
 	var resultErr error
+
+	// attempt is incremented when the input is rejected
+	attempt := 1
 	for {
 
 		// Construct the asking line to input
@@ -62,35 +249,64 @@ func (i *UI) Select(query string, list []string, opts *Options) (string, error)
 
 		// Add default val if provided
 		if defaultIndex >= 0 && !opts.HideDefault {
-			buf.WriteString(fmt.Sprintf(" (Default is %d)", defaultIndex+1))
+			buf.WriteString(fmt.Sprintf(" (Default is %d)", numbers[defaultIndex]))
 		}
 
 		buf.WriteString(": ")
-		fmt.Fprintf(i.Writer, buf.String())
+		fmt.Fprint(i.Writer, buf.String())
 
 		// Read user input from reader.
-		line, err := i.read(opts.readOpts())
+		line, err := i.readContext(ctx, opts.readOpts())
+		if err == context.DeadlineExceeded && opts.DefaultOnTimeout && defaultIndex >= 0 {
+			resultIndex = defaultIndex
+			break
+		}
+
 		if err != nil {
 			resultErr = err
 			break
 		}
 
+		// Go back to the previous question
+		if opts.BackCommand != "" && line == opts.BackCommand {
+			resultErr = ErrBack
+			break
+		}
+
 		// line is empty but default is provided returns it
 		if line == "" && defaultIndex >= 0 {
-			resultStr = list[defaultIndex]
+			resultIndex = defaultIndex
 			break
 		}
 
 		if line == "" && opts.Required {
-			if !opts.Loop {
-				resultErr = ErrEmpty
+			if err := opts.giveUp(attempt, ErrEmpty); err != nil {
+				resultErr = err
 				break
 			}
 
+			attempt++
 			fmt.Fprintf(i.Writer, "Input must not be empty. Answer by a number.\n\n")
 			continue
 		}
 
+		// Turn the page if the list is paged
+		paged := opts.PageSize > 0 && len(listing) > opts.PageSize
+		if paged && (line == "n" || line == "p") {
+			if line == "n" && (page+1)*opts.PageSize < len(listing) {
+				page++
+			}
+
+			if line == "p" && page > 0 {
+				page--
+			}
+
+			var buf bytes.Buffer
+			writeItems(&buf, list, numbers, listing, page, opts.PageSize, dim)
+			fmt.Fprint(i.Writer, buf.String())
+			continue
+		}
+
 		// Convert user input string to int val
 		n, err := strconv.Atoi(line)
 		if err != nil {
@@ -99,43 +315,191 @@ func (i *UI) Select(query string, list []string, opts *Options) (string, error)
 				break
 			}
 
-			fmt.Fprintf(i.Writer,
-				"%q is not a valid input. Answer by a number.\n\n", line)
+			// Treat the input as the query to narrow the list.
+			// The items keep the numbers of the original list.
+			var indexes []int
+			matched, _ := filterItems(line, labels(list))
+			for _, index := range matched {
+				if !list[index].Heading {
+					indexes = append(indexes, index)
+				}
+			}
+
+			if len(indexes) == 0 {
+				if err := opts.giveUp(attempt, ErrNotNumber); err != nil {
+					resultErr = err
+					break
+				}
+
+				attempt++
+				fmt.Fprintf(i.Writer,
+					"%q does not match any item. Answer by a number.\n\n", line)
+				continue
+			}
+
+			listing, page = indexes, 0
+
+			var buf bytes.Buffer
+			buf.WriteString(fmt.Sprintf("Items matching %q:\n\n", line))
+			writeItems(&buf, list, numbers, listing, page, opts.PageSize, dim)
+			fmt.Fprint(i.Writer, buf.String())
 			continue
 		}
 
 		// Check answer is in range of list
-		if n < 1 || len(list) < n {
-			if !opts.Loop {
-				resultErr = ErrOutOfRange
+		if n < 1 || len(numbered) < n {
+			if err := opts.giveUp(attempt, ErrOutOfRange); err != nil {
+				resultErr = err
 				break
 			}
 
+			attempt++
 			fmt.Fprintf(i.Writer,
 				"%q is not a valid choice. Choose a number from 1 to %d.\n\n",
-				line, len(list))
+				line, len(numbered))
+			continue
+		}
+
+		// Check the item can be selected
+		if item := list[numbered[n-1]]; item.Disabled {
+			if err := opts.giveUp(attempt, ErrDisabled); err != nil {
+				resultErr = err
+				break
+			}
+
+			attempt++
+			fmt.Fprintf(i.Writer, "%s\n\n", disabledMessage(item))
 			continue
 		}
 
 		// validate input by custom function
-		validate := opts.validateFunc()
-		if err := validate(line); err != nil {
-			if !opts.Loop {
+		if err := opts.validate(line, attempt); err != nil {
+			if err := opts.giveUp(attempt, err); err != nil {
 				resultErr = err
 				break
 			}
 
+			attempt++
 			fmt.Fprintf(i.Writer, "Failed to validate input string: %s\n\n", err)
 			continue
 		}
 
 		// Reach here means it gets ideal input.
-		resultStr = list[n-1]
+		resultIndex = numbered[n-1]
 		break
 	}
 
 	// Insert the new line for next output
 	fmt.Fprintf(i.Writer, "\n")
 
-	return resultStr, resultErr
+	return resultIndex, resultErr
+}
+
+// resolveItem returns the index of the first enabled item whose label
+// is the resolved answer. It returns the error if no item has the
+// label or the items can not be selected.
+func resolveItem(list []Item, resolved string, opts *Options) (int, error) {
+	numbers, _ := numberItems(list)
+	disabled := false
+	for n, item := range list {
+		if item.Heading || item.Label != resolved {
+			continue
+		}
+
+		// Another item may have the same label
+		if item.Disabled {
+			disabled = true
+			continue
+		}
+
+		if err := opts.validate(strconv.Itoa(numbers[n]), 1); err != nil {
+			return -1, err
+		}
+
+		return n, nil
+	}
+
+	if disabled {
+		return -1, ErrDisabled
+	}
+
+	return -1, ErrOutOfRange
+}
+
+// writeItems writes the numbered items of the list which indexes
+// indicate. numbers are the numbers of the items in the list (see
+// numberItems). If pageSize is more than 0, only the items of the
+// given page are written with the guide to turn the page. If dim is
+// true, the descriptions and the disabled items are dimmed.
+func writeItems(buf *bytes.Buffer, list []Item, numbers, indexes []int, page, pageSize int, dim bool) {
+	start, end := 0, len(indexes)
+	if pageSize > 0 && len(indexes) > pageSize {
+		start = page * pageSize
+		if end > start+pageSize {
+			end = start + pageSize
+		}
+	}
+
+	// Align the descriptions
+	width := labelWidth(list, indexes[start:end])
+	for _, index := range indexes[start:end] {
+		item := list[index]
+		if item.Heading {
+			buf.WriteString(fmt.Sprintf("%s\n", item.Label))
+			continue
+		}
+
+		line := fmt.Sprintf("%d. %s", numbers[index], formatItem(item, width, dim))
+		if item.Disabled && dim {
+			line = escDim + line + escNormal
+		}
+		buf.WriteString(line + "\n")
+	}
+
+	if end-start < len(indexes) {
+		buf.WriteString(fmt.Sprintf("\nShowing %d-%d of %d. Enter n or p to see the next or previous page.\n",
+			start+1, end, len(indexes)))
+	}
+
+	buf.WriteString("\n")
+}
+
+// labelWidth returns the width of the longest label of the items
+// which indexes indicate.
+func labelWidth(list []Item, indexes []int) int {
+	var width int
+	for _, index := range indexes {
+		if list[index].Heading {
+			continue
+		}
+
+		if n := stringWidth(list[index].Label); n > width {
+			width = n
+		}
+	}
+
+	return width
+}
+
+// formatItem formats the item for display. If the item has the
+// description, it's displayed in the second column after the label
+// which is padded to the given width.
+func formatItem(it Item, width int, dim bool) string {
+	if it.Description == "" {
+		return it.Label
+	}
+
+	padding := strings.Repeat(" ", width-stringWidth(it.Label))
+	description := it.Description
+	if dim {
+		description = escDim + description + escNormal
+	}
+
+	return fmt.Sprintf("%s%s  %s", it.Label, padding, description)
+}
+
+// isTerminalWriter returns true if w is a terminal.
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && isTerminal(f)
 }
@@ -2,10 +2,14 @@ package input
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSelect(t *testing.T) {
@@ -98,6 +102,367 @@ func TestSelect(t *testing.T) {
 	}
 }
 
+func TestSelect_filter(t *testing.T) {
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString("prd\nxyz\n3\n"),
+	}
+
+	ans, err := ui.Select("Which?", []string{"dev", "staging", "production"}, &Options{
+		Loop: true,
+	})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if ans != "production" {
+		t.Fatalf("expect %q to be eq %q", ans, "production")
+	}
+
+	// Narrowed list keeps the original numbers
+	expect := "Items matching \"prd\":\n\n3. production\n\n"
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+
+	expect = "\"xyz\" does not match any item."
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+}
+
+func TestSelect_filterMaxAttempts(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString(strings.Repeat("zzz\n", 50) + "1\n"),
+	}
+
+	_, err := ui.Select("Which?", []string{"dev", "staging", "production"}, &Options{
+		Loop:        true,
+		MaxAttempts: 2,
+	})
+
+	// The input which matches nothing is rejected
+	if !errors.Is(err, ErrTooManyAttempts) || !errors.Is(err, ErrNotNumber) {
+		t.Fatalf("expect %v to be %v", err, ErrTooManyAttempts)
+	}
+}
+
+func TestSelect_page(t *testing.T) {
+	var list []string
+	for i := 1; i <= 25; i++ {
+		list = append(list, fmt.Sprintf("item%d", i))
+	}
+
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString("n\nn\nn\np\n25\n"),
+	}
+
+	ans, err := ui.Select("Which?", list, &Options{
+		PageSize: 10,
+	})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	// Items keep their numbers across pages
+	if ans != "item25" {
+		t.Fatalf("expect %q to be eq %q", ans, "item25")
+	}
+
+	outStr := out.String()
+	for _, expect := range []string{
+		"1. item1\n",
+		"\nShowing 1-10 of 25.",
+		"11. item11\n",
+		"\nShowing 11-20 of 25.",
+		"21. item21\n",
+		"\nShowing 21-25 of 25.",
+	} {
+		if !strings.Contains(outStr, expect) {
+			t.Fatalf("expect %q to contain %q", outStr, expect)
+		}
+	}
+
+	if strings.Contains(outStr, "item11\nitem") {
+		t.Fatalf("expect only a page to be displayed at once: %q", outStr)
+	}
+
+	// Turning the page after the last page shows the last page again
+	if n := strings.Count(outStr, "Showing 21-25 of 25."); n != 2 {
+		t.Fatalf("expect the last page to be displayed twice: %d", n)
+	}
+}
+
+func TestSelect_notNumber(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("A\n"),
+	}
+
+	_, err := ui.Select("Which?", []string{"A", "B", "C"}, &Options{})
+	if err != ErrNotNumber {
+		t.Fatalf("expect %q to be eq %q", err, ErrNotNumber)
+	}
+}
+
+func TestSelectIndex(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("3\n"),
+	}
+
+	// Duplicated items can be distinguished by the index
+	n, err := ui.SelectIndex("Which?", []string{"A", "B", "A"}, &Options{})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if n != 2 {
+		t.Fatalf("expect %d to be eq %d", n, 2)
+	}
+}
+
+func TestSelectItems(t *testing.T) {
+	items := []Item{
+		{Label: "Staging", Heading: true},
+		{Label: "stg"},
+		{Label: "Production", Heading: true},
+		{Label: "prod (locked)", Disabled: true, Reason: "deploy freeze"},
+		{Label: "prod-canary"},
+	}
+
+	cases := []struct {
+		opts      *Options
+		userInput string
+		expect    int
+	}{
+		{
+			opts:      &Options{},
+			userInput: "1\n",
+			expect:    1,
+		},
+
+		// Headings are not numbered
+		{
+			opts:      &Options{},
+			userInput: "3\n",
+			expect:    4,
+		},
+
+		{
+			opts: &Options{
+				Default: "prod-canary",
+			},
+			userInput: "\n",
+			expect:    4,
+		},
+
+		// Loop
+		{
+			opts: &Options{
+				Loop: true,
+			},
+			userInput: "2\n4\nprod\n3\n",
+			expect:    4,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: bytes.NewBufferString(c.userInput),
+		}
+
+		n, err := ui.SelectItems("Where?", items, c.opts)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if n != c.expect {
+			t.Fatalf("#%d expect %d to be eq %d", i, n, c.expect)
+		}
+	}
+}
+
+func TestSelectItems_disabled(t *testing.T) {
+	items := []Item{
+		{Label: "Production", Heading: true},
+		{Label: "prod", Disabled: true, Reason: "deploy freeze"},
+	}
+
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString("1\n"),
+	}
+
+	_, err := ui.SelectItems("Where?", items, &Options{})
+	if err != ErrDisabled {
+		t.Fatalf("expect %q to be eq %q", err, ErrDisabled)
+	}
+
+	expect := "Where?\n\nProduction\n1. prod\n\n"
+	if !strings.HasPrefix(out.String(), expect) {
+		t.Fatalf("expect %q to have prefix %q", out.String(), expect)
+	}
+
+	ui = &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString("1\n2\n"),
+	}
+
+	out.Reset()
+	items = append(items, Item{Label: "prod-canary"})
+	if _, err := ui.SelectItems("Where?", items, &Options{Loop: true}); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	expect = "\"prod\" can not be selected: deploy freeze"
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+}
+
+func TestSelectItems_disabledDefault(t *testing.T) {
+	items := []Item{
+		{Label: "a", Disabled: true},
+		{Label: "b"},
+	}
+
+	for _, nonInteractive := range []bool{false, true} {
+		ui := &UI{
+			Writer:         ioutil.Discard,
+			Reader:         bytes.NewBufferString("\n"),
+			NonInteractive: nonInteractive,
+		}
+
+		// The disabled item can not be the default
+		n, err := ui.SelectItems("Which?", items, &Options{Default: "a"})
+		if err == nil {
+			t.Fatalf("expect error to occurr: %d", n)
+		}
+	}
+
+	// The enabled item of the same label is the default
+	items = append(items, Item{Label: "a"})
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("\n"),
+	}
+
+	n, err := ui.SelectItems("Which?", items, &Options{Default: "a"})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if n != 2 {
+		t.Fatalf("expect %d to be eq %d", n, 2)
+	}
+}
+
+func TestSelectItems_resolvedDisabled(t *testing.T) {
+	items := []Item{
+		{Label: "prod", Disabled: true, Reason: "deploy freeze"},
+		{Label: "stg"},
+	}
+
+	ui := &UI{
+		Writer:         ioutil.Discard,
+		Reader:         bytes.NewBufferString(""),
+		NonInteractive: true,
+		Resolvers:      []Resolver{MapResolver{"env": "prod"}},
+	}
+
+	if _, err := ui.SelectItems("Where?", items, &Options{Key: "env"}); !errors.Is(err, ErrDisabled) {
+		t.Fatalf("expect %v to be %v", err, ErrDisabled)
+	}
+
+	// The enabled item of the same label is selected
+	items = append(items, Item{Label: "prod"})
+	n, err := ui.SelectItems("Where?", items, &Options{Key: "env"})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if n != 2 {
+		t.Fatalf("expect %d to be eq %d", n, 2)
+	}
+}
+
+func TestSelectItems_description(t *testing.T) {
+	items := []Item{
+		{Label: "tokyo", Description: "ap-northeast-1"},
+		{Label: "東京", Description: "ap-northeast-1"},
+		{Label: "大阪リージョン", Description: "ap-northeast-3"},
+	}
+
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString("1\n"),
+	}
+
+	if _, err := ui.SelectItems("Where?", items, &Options{}); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	// Descriptions are aligned by the width of the wide characters
+	expect := "1. tokyo           ap-northeast-1\n" +
+		"2. 東京            ap-northeast-1\n" +
+		"3. 大阪リージョン  ap-northeast-3\n"
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+}
+
+func TestSelectContext(t *testing.T) {
+	cases := []struct {
+		opts      *Options
+		expect    string
+		expectErr error
+	}{
+		{
+			opts:      &Options{},
+			expectErr: context.DeadlineExceeded,
+		},
+
+		{
+			opts: &Options{
+				Default:          "B",
+				DefaultOnTimeout: true,
+			},
+			expect: "B",
+		},
+	}
+
+	for i, c := range cases {
+		// The reader which never returns the input
+		r, w := io.Pipe()
+		defer w.Close()
+
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: r,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		ans, err := ui.SelectContext(ctx, "Which?", []string{"A", "B", "C"}, c.opts)
+		if err != c.expectErr {
+			t.Fatalf("#%d expect %v to be eq %v", i, err, c.expectErr)
+		}
+
+		if ans != c.expect {
+			t.Fatalf("#%d expect %q to be eq %q", i, ans, c.expect)
+		}
+	}
+}
+
 func TestSelect_invalidDefault(t *testing.T) {
 	ui := &UI{
 		Writer: ioutil.Discard,
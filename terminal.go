@@ -0,0 +1,92 @@
+package input
+
+import (
+	"os"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Terminal controls the terminal which UI reads the input from. It's
+// used to read the masked input without echo and to select the item
+// interactively. The input itself is read from UI.Reader.
+//
+// By default, if UI.Reader is *os.File, the terminal connected to it is
+// used. Implement it to use those features with the other readers, e.g.,
+// SSH channels or websockets. If it also has Size() (width, height int,
+// err error) method, the interactive Select fits the list to the height.
+type Terminal interface {
+	// IsTerminal returns true if the reader is a terminal. If it
+	// returns false, the input is read without raw mode.
+	IsTerminal() bool
+
+	// MakeRaw puts the terminal into raw mode where each key is
+	// read immediately without echo.
+	MakeRaw() error
+
+	// Restore restores the terminal to the state before MakeRaw.
+	Restore() error
+}
+
+// sizer is implemented by Terminal which knows its size.
+type sizer interface {
+	Size() (width, height int, err error)
+}
+
+// terminal returns the Terminal of the reader. It returns nil if the
+// reader is not a terminal.
+func (i *UI) terminal() Terminal {
+	t := i.Terminal
+	if t == nil {
+		f, ok := i.Reader.(*os.File)
+		if !ok {
+			return nil
+		}
+
+		t = &fileTerminal{f: f}
+	}
+
+	if !t.IsTerminal() {
+		return nil
+	}
+
+	return t
+}
+
+// fileTerminal is Terminal connected to the file.
+type fileTerminal struct {
+	f *os.File
+
+	// restore restores the state before MakeRaw.
+	restore func()
+}
+
+// IsTerminal returns true if the file is a terminal.
+func (t *fileTerminal) IsTerminal() bool {
+	return isTerminal(t.f)
+}
+
+// MakeRaw puts the terminal into raw mode.
+func (t *fileTerminal) MakeRaw() error {
+	restore, err := rawMode(t.f)
+	if err != nil {
+		return err
+	}
+
+	t.restore = restore
+	return nil
+}
+
+// Restore restores the terminal to the state before MakeRaw.
+func (t *fileTerminal) Restore() error {
+	if t.restore != nil {
+		t.restore()
+		t.restore = nil
+	}
+
+	return nil
+}
+
+// Size returns the size of the terminal.
+func (t *fileTerminal) Size() (int, int, error) {
+	return terminal.GetSize(int(t.f.Fd()))
+}
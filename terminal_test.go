@@ -0,0 +1,116 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// fakeTerminal is Terminal which records the calls.
+type fakeTerminal struct {
+	terminal bool
+	calls    []string
+}
+
+func (t *fakeTerminal) IsTerminal() bool {
+	return t.terminal
+}
+
+func (t *fakeTerminal) MakeRaw() error {
+	t.calls = append(t.calls, "MakeRaw")
+	return nil
+}
+
+func (t *fakeTerminal) Restore() error {
+	t.calls = append(t.calls, "Restore")
+	return nil
+}
+
+func TestTerminal(t *testing.T) {
+	cases := []struct {
+		terminal    bool
+		userInput   io.Reader
+		expectCalls []string
+	}{
+		{
+			terminal:    true,
+			userInput:   bytes.NewBufferString("passw0rd\r"),
+			expectCalls: []string{"MakeRaw", "Restore"},
+		},
+
+		{
+			terminal:  false,
+			userInput: bytes.NewBufferString("passw0rd\n"),
+		},
+	}
+
+	for i, c := range cases {
+		var buf bytes.Buffer
+		term := &fakeTerminal{terminal: c.terminal}
+		ui := &UI{
+			Writer:   &buf,
+			Reader:   c.userInput,
+			Terminal: term,
+		}
+
+		ans, err := ui.Ask("Password", &Options{Mask: true, MaskVal: "●"})
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if ans != "passw0rd" {
+			t.Fatalf("#%d expect %q to be eq %q", i, ans, "passw0rd")
+		}
+
+		if fmt.Sprint(term.calls) != fmt.Sprint(c.expectCalls) {
+			t.Fatalf("#%d expect %v to be eq %v", i, term.calls, c.expectCalls)
+		}
+
+		if !bytes.Contains(buf.Bytes(), []byte("●●●●●●●●\n")) {
+			t.Fatalf("#%d expect the input to be masked: %q", i, buf.String())
+		}
+	}
+}
+
+func TestTerminal_strengthMeter(t *testing.T) {
+	for _, terminal := range []bool{true, false} {
+		var buf bytes.Buffer
+		ui := &UI{
+			Writer:   &buf,
+			Reader:   bytes.NewBufferString("passw0rd\r"),
+			Terminal: &fakeTerminal{terminal: terminal},
+		}
+
+		if _, err := ui.Ask("Password", &Options{Mask: true, StrengthMeter: true}); err != nil {
+			t.Fatalf("expect not to occurr error: %s", err)
+		}
+
+		// The meter is displayed only on the terminal
+		if bytes.Contains(buf.Bytes(), []byte("[#--] weak")) != terminal {
+			t.Fatalf("expect the meter to be displayed (%v): %q", terminal, buf.String())
+		}
+	}
+}
+
+func TestTerminal_interactive(t *testing.T) {
+	term := &fakeTerminal{terminal: true}
+	ui := &UI{
+		Writer:   &bytes.Buffer{},
+		Reader:   bytes.NewBufferString("j\r"),
+		Terminal: term,
+	}
+
+	ans, err := ui.Select("Which?", []string{"A", "B", "C"}, &Options{Interactive: true})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if ans != "B" {
+		t.Fatalf("expect %q to be eq %q", ans, "B")
+	}
+
+	if fmt.Sprint(term.calls) != "[MakeRaw Restore]" {
+		t.Fatalf("expect %v to be eq %v", term.calls, "[MakeRaw Restore]")
+	}
+}
@@ -0,0 +1,50 @@
+package input
+
+import "os"
+
+// openTTY opens the controlling terminal and returns the files to read
+// from and write to it. It's replaced in tests.
+var openTTY = openControllingTTY
+
+// TTYUI returns UI which reads from stdin and writes to stdout, or
+// from and to the controlling terminal when they are not terminals.
+// It's useful to ask the user even when the input of the command is
+// piped, e.g., `cat manifest.yaml | tool apply`. It returns ErrNoTTY
+// if there is no controlling terminal (e.g., running in CI).
+func TTYUI() (*UI, error) {
+	ui := &UI{
+		Writer: os.Stdout,
+		Reader: os.Stdin,
+		TTY:    true,
+	}
+
+	if err := ui.setup(); err != nil {
+		return nil, err
+	}
+
+	return ui, nil
+}
+
+// useTTY replaces Reader and Writer which are not terminals with
+// the controlling terminal.
+func (i *UI) useTTY() error {
+	readerTTY, writerTTY := i.terminal() != nil, isTerminalWriter(i.Writer)
+	if readerTTY && writerTTY {
+		return nil
+	}
+
+	in, out, err := openTTY()
+	if err != nil {
+		return ErrNoTTY
+	}
+
+	if !readerTTY {
+		i.Reader = in
+	}
+
+	if !writerTTY {
+		i.Writer = out
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestUI_TTY(t *testing.T) {
+	in, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	out, err := ioutil.TempFile("", "go-input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+
+	defer func(f func() (*os.File, *os.File, error)) { openTTY = f }(openTTY)
+	openTTY = func() (*os.File, *os.File, error) {
+		return in, out, nil
+	}
+
+	w.Write([]byte("y\n"))
+	w.Close()
+
+	// Reader and Writer are not terminals
+	var stdout bytes.Buffer
+	ui := &UI{
+		Writer: &stdout,
+		Reader: bytes.NewBufferString("n\n"),
+		TTY:    true,
+	}
+
+	ans, err := ui.Ask("Apply 12 changes?", &Options{})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if ans != "y" {
+		t.Fatalf("expect %q to be eq %q", ans, "y")
+	}
+
+	if stdout.Len() != 0 {
+		t.Fatalf("expect nothing to be written to Writer: %q", stdout.String())
+	}
+
+	written, err := ioutil.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Contains(written, []byte("Apply 12 changes?")) {
+		t.Fatalf("expect the query to be written to the terminal: %q", written)
+	}
+}
+
+func TestUI_TTY_noTTY(t *testing.T) {
+	defer func(f func() (*os.File, *os.File, error)) { openTTY = f }(openTTY)
+	openTTY = func() (*os.File, *os.File, error) {
+		return nil, nil, errors.New("open /dev/tty: no such device or address")
+	}
+
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("y\n"),
+		TTY:    true,
+	}
+
+	if _, err := ui.Ask("Apply 12 changes?", &Options{}); err != ErrNoTTY {
+		t.Fatalf("expect %v to be eq %v", err, ErrNoTTY)
+	}
+
+	if _, err := ui.Select("Which?", []string{"A", "B"}, &Options{}); err != ErrNoTTY {
+		t.Fatalf("expect %v to be eq %v", err, ErrNoTTY)
+	}
+}
@@ -0,0 +1,119 @@
+package input
+
+import (
+	"context"
+	"fmt"
+)
+
+// Parser converts the user input string to a value of type T and
+// formats the value of type T back to string to display it (e.g.,
+// as the default value).
+type Parser[T any] struct {
+	// Parse converts the user input to T. If it returns error,
+	// the input is treated same as the one which fails ValidateFunc.
+	Parse func(string) (T, error)
+
+	// Format converts T to string. If it's nil, fmt.Sprint
+	// is used.
+	Format func(T) string
+}
+
+// format formats v by p.Format.
+func (p Parser[T]) format(v T) string {
+	if p.Format == nil {
+		return fmt.Sprint(v)
+	}
+
+	return p.Format(v)
+}
+
+// AskValue asks the user for input using the given query and returns
+// it converted by the given parser. The input is parsed before
+// ValidateFunc is called and the parse error is handled same as
+// the validation error, i.e., if Loop is true, it continue to ask
+// until it receives the input which can be parsed.
+//
+// If def is not nil, it is used when nothing is input (and is
+// displayed by p.Format). Otherwise opts.Default is parsed and used.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func AskValue[T any](ui *UI, query string, p Parser[T], def *T, opts *Options) (T, error) {
+	return askValue(ui, query, "", p, def, opts)
+}
+
+// askValue is the implementation of AskValue. hint is added to
+// the instruction line.
+func askValue[T any](ui *UI, query, hint string, p Parser[T], def *T, opts *Options) (T, error) {
+	var zero T
+
+	// Don't modify the given options
+	o := *opts
+	if def != nil {
+		o.Default = p.format(*def)
+	}
+
+	ans, err := ui.ask(context.Background(), query, hint, &o, func(s string, attempt int) error {
+		// The empty input is validated only when neither Default nor
+		// Required is provided, and it's returned as ErrEmpty below.
+		if s == "" {
+			return o.validate(s, attempt)
+		}
+
+		if _, err := p.Parse(s); err != nil {
+			return err
+		}
+
+		return o.validate(s, attempt)
+	})
+	if err != nil {
+		return zero, err
+	}
+
+	// Reach here with empty string only when Default and Required
+	// are not provided.
+	if ans == "" {
+		return zero, ErrEmpty
+	}
+
+	if def != nil && ans == o.Default {
+		return *def, nil
+	}
+
+	v, err := p.Parse(ans)
+	if err != nil {
+		// This error message is not for user
+		// Should be found while development
+		return zero, fmt.Errorf("opt.Default is specified but it is invalid: %s", err)
+	}
+
+	return v, nil
+}
+
+// SelectValue asks the user to select a value from the given list. It
+// behaves same as Select, but each value is displayed by label and, if
+// description is not nil, the description is displayed dimmed in the
+// second column. opts.Default is compared with the labels. It returns
+// the selected value and its index in the list, so the values which
+// have the same label can be distinguished.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func SelectValue[T any](ui *UI, query string, list []T, label, description func(T) string, opts *Options) (T, int, error) {
+	var zero T
+
+	items := make([]Item, len(list))
+	for i, v := range list {
+		items[i].Label = label(v)
+		if description != nil {
+			items[i].Description = description(v)
+		}
+	}
+
+	n, err := ui.selectIndex(context.Background(), query, items, opts)
+	if err != nil {
+		return zero, -1, err
+	}
+
+	return list[n], n, nil
+}
@@ -0,0 +1,230 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAskValue(t *testing.T) {
+	def := 30 * time.Second
+	cases := []struct {
+		def       *time.Duration
+		opts      *Options
+		userInput string
+		expect    time.Duration
+	}{
+		{
+			opts:      &Options{},
+			userInput: "1h30m\n",
+			expect:    90 * time.Minute,
+		},
+
+		{
+			def:       &def,
+			opts:      &Options{},
+			userInput: "\n",
+			expect:    30 * time.Second,
+		},
+
+		{
+			opts: &Options{
+				Default: "5m",
+			},
+			userInput: "\n",
+			expect:    5 * time.Minute,
+		},
+
+		// Loop
+		{
+			opts: &Options{
+				Loop: true,
+			},
+			userInput: "ten minutes\n10m\n",
+			expect:    10 * time.Minute,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: bytes.NewBufferString(c.userInput),
+		}
+
+		ans, err := AskValue(ui, "", DurationParser, c.def, c.opts)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if ans != c.expect {
+			t.Fatalf("#%d expect %s to be eq %s", i, ans, c.expect)
+		}
+	}
+}
+
+func TestAskValue_error(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("ten minutes\n"),
+	}
+
+	_, err := AskValue(ui, "", DurationParser, nil, &Options{})
+	if err == nil {
+		t.Fatal("expect err to be occurr")
+	}
+}
+
+func TestAskValue_empty(t *testing.T) {
+	ui := &UI{
+		Writer: ioutil.Discard,
+		Reader: bytes.NewBufferString("\n"),
+	}
+
+	// The empty input is returned as ErrEmpty, not as the error
+	// of the parser
+	if _, err := AskValue(ui, "", DurationParser, nil, &Options{}); err != ErrEmpty {
+		t.Fatalf("expect %v to be eq %v", err, ErrEmpty)
+	}
+}
+
+func TestAskValue_displayDefault(t *testing.T) {
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString("\n"),
+	}
+
+	def := uint64(10 << 30)
+	if _, err := AskValue(ui, "Disk size?", ByteSizeParser, &def, &Options{}); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	expect := "(Default is 10GiB)"
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+}
+
+func ExampleAskValue() {
+	ui := &UI{
+		// In real world, Reader is os.Stdin and input comes
+		// from user actual input.
+		Reader: bytes.NewBufferString("1m30s\n"),
+		Writer: ioutil.Discard,
+	}
+
+	query := "How long to wait?"
+	d, _ := AskValue(ui, query, DurationParser, nil, &Options{
+		Loop: true,
+	})
+
+	fmt.Println(d.Seconds())
+	// Output: 90
+}
+
+type testLang struct {
+	name string
+	desc string
+}
+
+func TestSelectValue(t *testing.T) {
+	list := []testLang{
+		{name: "go", desc: "lower case"},
+		{name: "Go", desc: "official"},
+		{name: "go", desc: "duplicated"},
+	}
+
+	cases := []struct {
+		opts      *Options
+		userInput string
+		expect    int
+	}{
+		{
+			opts:      &Options{},
+			userInput: "3\n",
+			expect:    2,
+		},
+
+		{
+			opts: &Options{
+				Default: "Go",
+			},
+			userInput: "\n",
+			expect:    1,
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: bytes.NewBufferString(c.userInput),
+		}
+
+		v, n, err := SelectValue(ui, "", list, func(l testLang) string { return l.name },
+			func(l testLang) string { return l.desc }, c.opts)
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if n != c.expect {
+			t.Fatalf("#%d expect %d to be eq %d", i, n, c.expect)
+		}
+
+		if v != list[c.expect] {
+			t.Fatalf("#%d expect %v to be eq %v", i, v, list[c.expect])
+		}
+	}
+}
+
+func TestSelectValue_description(t *testing.T) {
+	var out bytes.Buffer
+	ui := &UI{
+		Writer: &out,
+		Reader: bytes.NewBufferString("1\n"),
+	}
+
+	list := []time.Duration{time.Second, time.Hour}
+	_, _, err := SelectValue(ui, "Timeout?", list, time.Duration.String, func(d time.Duration) string {
+		return fmt.Sprintf("%.0f seconds", d.Seconds())
+	}, &Options{})
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	// Descriptions are aligned in the second column
+	expect := "1. 1s      1 seconds\n2. 1h0m0s  3600 seconds\n"
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("expect %q to contain %q", out.String(), expect)
+	}
+}
+
+func ExampleSelectValue() {
+	ui := &UI{
+		// In real world, Reader is os.Stdin and input comes
+		// from user actual input.
+		Reader: bytes.NewBufferString("2\n"),
+		Writer: ioutil.Discard,
+	}
+
+	type region struct {
+		ID   string
+		Name string
+	}
+
+	regions := []region{
+		{ID: "us-east-1", Name: "US East (N. Virginia)"},
+		{ID: "ap-northeast-1", Name: "Asia Pacific (Tokyo)"},
+	}
+
+	query := "Which region do you deploy to?"
+	r, _, _ := SelectValue(ui, query, regions,
+		func(r region) string { return r.ID },
+		func(r region) string { return r.Name },
+		&Options{})
+
+	fmt.Println(r.Name)
+	// Output: Asia Pacific (Tokyo)
+}
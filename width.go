@@ -0,0 +1,53 @@
+package input
+
+import "unicode"
+
+// wideRanges are the ranges of the East Asian Wide and Fullwidth
+// characters which take two columns on the terminal.
+var wideRanges = []struct {
+	lo, hi rune
+}{
+	{0x1100, 0x115F},   // Hangul Jamo
+	{0x2E80, 0x303E},   // CJK Radicals, Kangxi Radicals, CJK Symbols and Punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, CJK Compatibility
+	{0x3400, 0x4DBF},   // CJK Unified Ideographs Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi Syllables and Radicals
+	{0xAC00, 0xD7A3},   // Hangul Syllables
+	{0xF900, 0xFAFF},   // CJK Compatibility Ideographs
+	{0xFE30, 0xFE4F},   // CJK Compatibility Forms
+	{0xFF00, 0xFF60},   // Fullwidth Forms
+	{0xFFE0, 0xFFE6},   // Fullwidth Signs
+	{0x1F300, 0x1F64F}, // Miscellaneous Symbols and Pictographs, Emoticons
+	{0x1F900, 0x1F9FF}, // Supplemental Symbols and Pictographs
+	{0x20000, 0x2FFFD}, // CJK Unified Ideographs Extension B and later
+	{0x30000, 0x3FFFD},
+}
+
+// runeWidth returns the number of columns which r takes on the
+// terminal. Combining marks and format characters take no column
+// and the wide characters take two.
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+
+	for _, rng := range wideRanges {
+		if rng.lo <= r && r <= rng.hi {
+			return 2
+		}
+	}
+
+	return 1
+}
+
+// stringWidth returns the number of columns which s takes on the
+// terminal.
+func stringWidth(s string) int {
+	var width int
+	for _, r := range s {
+		width += runeWidth(r)
+	}
+
+	return width
+}
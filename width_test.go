@@ -0,0 +1,26 @@
+package input
+
+import "testing"
+
+func TestStringWidth(t *testing.T) {
+	cases := []struct {
+		in     string
+		expect int
+	}{
+		{in: "", expect: 0},
+		{in: "taichi", expect: 6},
+		{in: "über", expect: 4},
+		{in: "über", expect: 4},
+		{in: "中村", expect: 4},
+		{in: "ﾅｶｼﾏ", expect: 4},
+		{in: "ｔａｉｃｈｉ", expect: 12},
+		{in: "●", expect: 1},
+		{in: "🔑", expect: 2},
+	}
+
+	for i, c := range cases {
+		if got := stringWidth(c.in); got != c.expect {
+			t.Fatalf("#%d expect %d to be eq %d", i, got, c.expect)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package input
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// defaultBackCommand is default Wizard.BackCommand.
+const defaultBackCommand = "<"
+
+// Answers are the answers of Wizard by the keys of the steps.
+type Answers map[string]string
+
+// Decode sets the answers to the fields of the struct which ptr points
+// to. The fields are matched by the keys same as Populate. The fields
+// which have no answer are set to their default values, or left as it
+// is if they have no default value (e.g., the skipped steps). It
+// returns PopulateError if some answers are invalid.
+func (a Answers) Decode(ptr interface{}) error {
+	ui := &UI{
+		Writer:         ioutil.Discard,
+		Reader:         strings.NewReader(""),
+		NonInteractive: true,
+		Resolvers:      []Resolver{MapResolver(a)},
+	}
+
+	err := ui.Populate(ptr)
+	perr, ok := err.(*PopulateError)
+	if !ok {
+		return err
+	}
+
+	// Ignore the fields which have no answer
+	var errs []*FieldError
+	for _, ferr := range perr.Errors {
+		if !errors.Is(ferr, ErrNonInteractive) {
+			errs = append(errs, ferr)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &PopulateError{Errors: errs}
+}
+
+// Step is a question of Wizard.
+type Step struct {
+	// Key is the key of the answer in Answers. It's also used as
+	// Options.Key, so the answer can be resolved by UI.Resolvers.
+	Key string
+
+	// Query is the question to the user.
+	Query string
+
+	// Choices makes the step ask the user to select one of them
+	// by Select. By default, it asks by Ask.
+	Choices []string
+
+	// Options is the options of the prompt. Key and BackCommand
+	// are set by Wizard.
+	Options Options
+
+	// When decides whether the step is asked by the answers of the
+	// previous steps. If it returns false, the step is skipped and
+	// has no answer. By default, the step is always asked.
+	When func(Answers) bool
+}
+
+// Wizard asks the user the sequential questions (steps). The user can
+// go back to the previous question by entering BackCommand (or the
+// left arrow key in interactive mode of Select) and answer it again
+// with the old answer as the default.
+type Wizard struct {
+	// UI is used to ask the questions. By default, it's the zero
+	// value of UI, i.e., it uses stdin and stdout.
+	UI *UI
+
+	// Steps are the questions which are asked in order.
+	Steps []Step
+
+	// BackCommand is the input to go back to the previous question.
+	// By default, it's "<".
+	BackCommand string
+
+	// Review displays the summary of the answers after the last step
+	// and lets the user change any of them by the number until the
+	// user confirms them. The masked answers are not displayed.
+	Review bool
+}
+
+// Run asks the steps in order and returns the answers by the keys of
+// the steps. If Review is true, it returns after the user confirms
+// the answers. To set them to the struct, use Answers.Decode.
+//
+// If the user sends SIGINT (Ctrl+C) while reading input, it catches
+// it and return it as a error.
+func (w *Wizard) Run() (Answers, error) {
+	return w.RunContext(context.Background())
+}
+
+// RunContext asks the steps same as Run, but returns ctx.Err() when
+// the context is done before the user answers all of them.
+func (w *Wizard) RunContext(ctx context.Context) (Answers, error) {
+	ui := w.UI
+	if ui == nil {
+		ui = &UI{}
+	}
+
+	if err := ui.setup(); err != nil {
+		return nil, err
+	}
+
+	for n, step := range w.Steps {
+		if step.Key == "" {
+			// This error message is not for user
+			// Should be found while development
+			return nil, fmt.Errorf("step %d has no Key", n)
+		}
+	}
+
+	answers := Answers{}
+	if err := w.runSteps(ctx, ui, answers, 0, false); err != nil {
+		return nil, err
+	}
+
+	if w.Review && !ui.nonInteractive() {
+		if err := w.review(ctx, ui, answers); err != nil {
+			return nil, err
+		}
+	}
+
+	return answers, nil
+}
+
+// backCommand returns BackCommand or its default value.
+func (w *Wizard) backCommand() string {
+	if w.BackCommand == "" {
+		return defaultBackCommand
+	}
+
+	return w.BackCommand
+}
+
+// runSteps asks the steps from the given index in order and sets the
+// answers. If keep is true, the steps which already have the answers
+// are not asked again.
+func (w *Wizard) runSteps(ctx context.Context, ui *UI, answers Answers, from int, keep bool) error {
+	// history is the indexes of the steps which the user answered.
+	// The resolved steps are not included, so they are skipped when
+	// the user goes back.
+	var history []int
+
+	for n := from; n < len(w.Steps); {
+		step := w.Steps[n]
+		if step.When != nil && !step.When(answers) {
+			delete(answers, step.Key)
+			n++
+			continue
+		}
+
+		if _, ok := answers[step.Key]; ok && keep {
+			n++
+			continue
+		}
+
+		_, resolved := ui.resolve(step.Key)
+
+		ans, err := w.askStep(ctx, ui, step, answers)
+		if err == ErrBack {
+			if len(history) == 0 {
+				fmt.Fprintf(ui.Writer, "This is the first question.\n\n")
+				continue
+			}
+
+			n, history = history[len(history)-1], history[:len(history)-1]
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		answers[step.Key] = ans
+		if !resolved && !ui.nonInteractive() {
+			history = append(history, n)
+		}
+		n++
+	}
+
+	return nil
+}
+
+// askStep asks the step. The old answer is used as the default.
+func (w *Wizard) askStep(ctx context.Context, ui *UI, step Step, answers Answers) (string, error) {
+	opts := step.Options
+	opts.Key = step.Key
+	opts.BackCommand = w.backCommand()
+
+	// Use the old answer as the default when the user goes back
+	if ans, ok := answers[step.Key]; ok && ans != "" {
+		opts.Default = ans
+	}
+	hint := opts.hideMaskedDefault()
+
+	if len(step.Choices) > 0 {
+		return ui.SelectContext(ctx, step.Query, step.Choices, &opts)
+	}
+
+	return ui.ask(ctx, step.Query, hint, &opts, opts.validate)
+}
+
+// review displays the summary of the answers and asks the user to
+// choose the answer to change until the user confirms them.
+func (w *Wizard) review(ctx context.Context, ui *UI, answers Answers) error {
+	for {
+		// numbered is the indexes of the answered steps by the number
+		var numbered []int
+		var queries, values []string
+		for n, step := range w.Steps {
+			ans, ok := answers[step.Key]
+			if !ok {
+				continue
+			}
+
+			numbered = append(numbered, n)
+			queries = append(queries, step.Query)
+			values = append(values, maskAnswer(&step.Options, ans))
+		}
+
+		var buf bytes.Buffer
+		buf.WriteString("Review the answers:\n\n")
+		width := 0
+		for _, query := range queries {
+			if n := stringWidth(query); n > width {
+				width = n
+			}
+		}
+
+		for n, query := range queries {
+			padding := strings.Repeat(" ", width-stringWidth(query))
+			buf.WriteString(fmt.Sprintf("%d. %s%s  %s\n", n+1, query, padding, values[n]))
+		}
+		buf.WriteString("\n")
+		fmt.Fprint(ui.Writer, buf.String())
+
+		line, err := ui.AskContext(ctx, "Enter a number to change the answer, or nothing to confirm", &Options{
+			HideOrder: true,
+			Loop:      true,
+			ValidateFunc: func(s string) error {
+				if s == "" {
+					return nil
+				}
+
+				n, err := strconv.Atoi(s)
+				if err != nil {
+					return ErrNotNumber
+				}
+
+				if n < 1 || len(numbered) < n {
+					return ErrOutOfRange
+				}
+
+				return nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Confirmed
+		if line == "" {
+			return nil
+		}
+
+		n, _ := strconv.Atoi(line)
+		index := numbered[n-1]
+		step := w.Steps[index]
+
+		ans, err := w.askChange(ctx, ui, step, answers)
+		if err == ErrBack {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+		answers[step.Key] = ans
+
+		// Ask the steps which are required by the new answer
+		if err := w.runSteps(ctx, ui, answers, index+1, true); err != nil {
+			return err
+		}
+	}
+}
+
+// askChange asks the step again to change the answer. The answer is
+// not resolved by UI.Resolvers.
+func (w *Wizard) askChange(ctx context.Context, ui *UI, step Step, answers Answers) (string, error) {
+	resolvers := ui.Resolvers
+	ui.Resolvers = nil
+	defer func() {
+		ui.Resolvers = resolvers
+	}()
+
+	return w.askStep(ctx, ui, step, answers)
+}
+
+// maskAnswer masks the answer of the prompt which has the given
+// options for the summary. The masked input is not revealed at all
+// and the answer of MaskDefault is masked same as the default value.
+func maskAnswer(opts *Options, ans string) string {
+	if opts.Mask || opts.Hide {
+		return maskString("")
+	}
+
+	if opts.MaskDefault {
+		return maskString(ans)
+	}
+
+	return ans
+}
@@ -0,0 +1,293 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBackCommand(t *testing.T) {
+	opts := &Options{BackCommand: "<"}
+
+	cases := []struct {
+		ask func(ui *UI) error
+	}{
+		{
+			ask: func(ui *UI) error {
+				_, err := ui.Ask("What is your name?", opts)
+				return err
+			},
+		},
+
+		{
+			ask: func(ui *UI) error {
+				_, err := ui.Select("Which region?", []string{"osaka", "tokyo"}, opts)
+				return err
+			},
+		},
+
+		{
+			ask: func(ui *UI) error {
+				_, err := ui.MultiSelect("Which regions?", []string{"osaka", "tokyo"}, &MultiSelectOptions{Options: *opts})
+				return err
+			},
+		},
+	}
+
+	for i, c := range cases {
+		ui := &UI{
+			Writer: ioutil.Discard,
+			Reader: bytes.NewBufferString("<\n"),
+		}
+
+		if err := c.ask(ui); err != ErrBack {
+			t.Fatalf("#%d expect %v to be eq %v", i, err, ErrBack)
+		}
+	}
+}
+
+func TestWizard(t *testing.T) {
+	isProd := func(answers Answers) bool {
+		return answers["env"] == "prod"
+	}
+
+	steps := []Step{
+		{Key: "name", Query: "What is your name?", Options: Options{Required: true}},
+		{Key: "env", Query: "Which environment?", Choices: []string{"dev", "prod"}},
+		{Key: "region", Query: "Which region?", When: isProd},
+		{Key: "port", Query: "Which port?", Options: Options{Default: "80"}},
+	}
+
+	cases := []struct {
+		userInput string
+		resolvers []Resolver
+		expect    Answers
+		expectOut []string
+	}{
+		{
+			userInput: "tcnksm\n2\ntokyo\n8080\n",
+			expect:    Answers{"name": "tcnksm", "env": "prod", "region": "tokyo", "port": "8080"},
+		},
+
+		// Go back and change the answer
+		{
+			userInput: "tcnksm\n2\n<\n1\n\n",
+			expect:    Answers{"name": "tcnksm", "env": "dev", "port": "80"},
+			expectOut: []string{"Enter a number (Default is 2)"},
+		},
+
+		// The skipped step is not asked when going back
+		{
+			userInput: "tcnksm\n1\n<\n<\n\n\n\n",
+			expect:    Answers{"name": "tcnksm", "env": "dev", "port": "80"},
+			expectOut: []string{"(Default is tcnksm)"},
+		},
+
+		{
+			userInput: "<\ntcnksm\n1\n\n",
+			expect:    Answers{"name": "tcnksm", "env": "dev", "port": "80"},
+			expectOut: []string{"This is the first question."},
+		},
+
+		// The resolved step is not asked when going back
+		{
+			userInput: "1\n<\n<\n2\ntokyo\n\n",
+			resolvers: []Resolver{MapResolver{"name": "tcnksm"}},
+			expect:    Answers{"name": "tcnksm", "env": "prod", "region": "tokyo", "port": "80"},
+			expectOut: []string{"This is the first question."},
+		},
+	}
+
+	for i, c := range cases {
+		var out bytes.Buffer
+		w := &Wizard{
+			UI: &UI{
+				Writer:    &out,
+				Reader:    bytes.NewBufferString(c.userInput),
+				Resolvers: c.resolvers,
+			},
+			Steps: steps,
+		}
+
+		answers, err := w.Run()
+		if err != nil {
+			t.Fatalf("#%d expect not to occurr error: %s", i, err)
+		}
+
+		if !reflect.DeepEqual(answers, c.expect) {
+			t.Fatalf("#%d expect %v to be eq %v", i, answers, c.expect)
+		}
+
+		for _, s := range c.expectOut {
+			if !strings.Contains(out.String(), s) {
+				t.Fatalf("#%d expect %q to be displayed: %q", i, s, out.String())
+			}
+		}
+	}
+}
+
+func TestWizard_mask(t *testing.T) {
+	var out bytes.Buffer
+	w := &Wizard{
+		UI: &UI{
+			Writer: &out,
+			// Go back to the masked step and keep the old answer
+			Reader: bytes.NewBufferString("hunter22\n<\n\ntcnksm\n"),
+		},
+		Steps: []Step{
+			{Key: "password", Query: "Password?", Options: Options{Mask: true}},
+			{Key: "name", Query: "What is your name?"},
+		},
+	}
+
+	answers, err := w.Run()
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	expect := Answers{"password": "hunter22", "name": "tcnksm"}
+	if !reflect.DeepEqual(answers, expect) {
+		t.Fatalf("expect %v to be eq %v", answers, expect)
+	}
+
+	if !strings.Contains(out.String(), "Enter a value (Default is *******): ") {
+		t.Fatalf("expect the default to be masked: %q", out.String())
+	}
+
+	// No part of the masked answer is displayed
+	if strings.Contains(out.String(), "hun") {
+		t.Fatalf("expect the masked answer not to be displayed: %q", out.String())
+	}
+}
+
+func TestWizard_review(t *testing.T) {
+	steps := []Step{
+		{Key: "name", Query: "What is your name?"},
+		{Key: "password", Query: "Password?", Options: Options{Mask: true}},
+		{Key: "env", Query: "Which environment?", Choices: []string{"dev", "prod"}},
+		{Key: "region", Query: "Which region?", When: func(answers Answers) bool {
+			return answers["env"] == "prod"
+		}},
+	}
+
+	var out bytes.Buffer
+	w := &Wizard{
+		UI: &UI{
+			Writer: &out,
+			Reader: bytes.NewBufferString(
+				"s3cr3t\n1\n" +
+					// Change the environment, which requires the region
+					"3\n2\ntokyo\n" +
+					// Invalid number and cancel changing the name
+					"9\n1\n<\n" +
+					// Change the environment back
+					"3\n1\n" +
+					"\n",
+			),
+			Resolvers: []Resolver{MapResolver{"name": "taichi"}},
+		},
+		Steps:  steps,
+		Review: true,
+	}
+
+	answers, err := w.Run()
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	expect := Answers{"name": "taichi", "password": "s3cr3t", "env": "dev"}
+	if !reflect.DeepEqual(answers, expect) {
+		t.Fatalf("expect %v to be eq %v", answers, expect)
+	}
+
+	summary := "Review the answers:\n\n" +
+		"1. What is your name?  taichi\n" +
+		"2. Password?           *******\n" +
+		"3. Which environment?  prod\n" +
+		"4. Which region?       tokyo\n"
+	if !strings.Contains(out.String(), summary) {
+		t.Fatalf("expect %q to be displayed: %q", summary, out.String())
+	}
+
+	if strings.Contains(out.String(), "s3cr3t") {
+		t.Fatalf("expect the masked answer not to be displayed")
+	}
+}
+
+func TestWizard_reviewMask(t *testing.T) {
+	var out bytes.Buffer
+	w := &Wizard{
+		UI: &UI{
+			Writer: &out,
+			// Change the masked answer, keep it and confirm
+			Reader: bytes.NewBufferString("hunter22\n1\n\n\n"),
+		},
+		Steps: []Step{
+			{Key: "password", Query: "Password?", Options: Options{Mask: true}},
+		},
+		Review: true,
+	}
+
+	answers, err := w.Run()
+	if err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if answers["password"] != "hunter22" {
+		t.Fatalf("expect %q to be eq %q", answers["password"], "hunter22")
+	}
+
+	if !strings.Contains(out.String(), "Enter a value (Default is *******): ") {
+		t.Fatalf("expect the default to be masked: %q", out.String())
+	}
+
+	// No part of the masked answer is displayed
+	if strings.Contains(out.String(), "hun") {
+		t.Fatalf("expect the masked answer not to be displayed: %q", out.String())
+	}
+}
+
+func TestWizard_interrupted(t *testing.T) {
+	w := &Wizard{
+		UI: &UI{
+			Writer: ioutil.Discard,
+			Reader: bytes.NewBufferString("tcnksm\n"),
+		},
+		Steps: []Step{
+			{Key: "name", Query: "What is your name?"},
+			{Key: "region", Query: "Which region?"},
+		},
+	}
+
+	if _, err := w.Run(); err != ErrEOF {
+		t.Fatalf("expect %v to be eq %v", err, ErrEOF)
+	}
+}
+
+func TestAnswers_Decode(t *testing.T) {
+	var cfg struct {
+		Name   string
+		Port   int
+		Region string `default:"osaka"`
+		Env    string
+	}
+	cfg.Env = "dev"
+
+	answers := Answers{"name": "tcnksm", "port": "8080"}
+	if err := answers.Decode(&cfg); err != nil {
+		t.Fatalf("expect not to occurr error: %s", err)
+	}
+
+	if cfg.Name != "tcnksm" || cfg.Port != 8080 || cfg.Region != "osaka" || cfg.Env != "dev" {
+		t.Fatalf("unexpected result: %+v", cfg)
+	}
+
+	answers = Answers{"port": "http"}
+	var perr *PopulateError
+	if err := answers.Decode(&cfg); !errors.As(err, &perr) || len(perr.Errors) != 1 {
+		t.Fatalf("expect %v to be PopulateError of the port", err)
+	}
+}